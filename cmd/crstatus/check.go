@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/openshift/custom-resource-status/conditions/v1/selector"
+)
+
+var checkCommand = &command{
+	name:  "check",
+	usage: "[flags] EXPRESSION [FILE...]",
+	description: `Check that the conditions of every resource in the manifests match EXPRESSION,
+a condition selector such as "Available=True,Degraded!=True". Selectors are
+comma-separated lists of requirements:
+
+  TYPE=STATUS                 TYPE is present with STATUS
+  TYPE!=STATUS                TYPE is absent or does not have STATUS
+  TYPE in (STATUS,...)        TYPE is present with one of the statuses
+  TYPE notin (STATUS,...)     TYPE is absent or has none of the statuses
+  TYPE                        TYPE is present
+  !TYPE                       TYPE is absent
+  reason(TYPE)=REASON         TYPE is present with REASON
+
+Values containing a comma must be double-quoted, as in reason(TYPE)="A,B".
+
+Exits with 0 when all the requirements hold, 1 when one does not hold, and 2 on error.`,
+	run: runCheck,
+}
+
+func runCheck(cmd *command, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := cmd.flagSet(stderr)
+	quiet := flags.Bool("q", false, "do not print anything, only exit with the result")
+	if ok, code := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() < 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	s, err := selector.Parse(flags.Arg(0))
+	if err != nil {
+		return fail(stderr, err)
+	}
+	resources, err := readResources(flags.Args()[1:], stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	if len(resources) == 0 {
+		return fail(stderr, fmt.Errorf("no resources found"))
+	}
+
+	code := exitOK
+	for _, res := range resources {
+		for _, requirement := range s.Requirements() {
+			if requirement.Matches(res.status.Conditions) {
+				continue
+			}
+			code = exitFailure
+			if !*quiet {
+				fmt.Fprintf(stdout, "%s: %s does not hold: %s\n", res, requirement, explain(requirement, res.status.Conditions))
+			}
+		}
+	}
+	if code == exitOK && !*quiet {
+		fmt.Fprintf(stdout, "%d resource(s) match %s\n", len(resources), flags.Arg(0))
+	}
+	return code
+}
+
+// explain describes the condition r was evaluated against.
+func explain(r selector.Requirement, conditions []conditionsv1.Condition) string {
+	condition := conditionsv1.FindStatusCondition(conditions, r.Type)
+	if condition == nil {
+		return fmt.Sprintf("%s is not present", r.Type)
+	}
+	explanation := fmt.Sprintf("%s is %s", condition.Type, condition.Status)
+	if condition.Reason != "" {
+		explanation += fmt.Sprintf(" (%s)", condition.Reason)
+	}
+	if condition.Message != "" {
+		explanation += ": " + condition.Message
+	}
+	return explanation
+}
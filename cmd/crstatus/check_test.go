@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expected     string
+	}{
+		{
+			name:         "does not hold for one resource",
+			args:         []string{"check", "Available=True,Degraded!=True", "testdata/exampleapps.yaml"},
+			expectedCode: exitFailure,
+			expected: `ExampleApp test-namespace/bar: Available=True does not hold: Available is not present
+`,
+		},
+		{
+			name:         "spaces and == are allowed",
+			args:         []string{"check", "Available == True, Degraded!=True, Upgradeable!=False", "testdata/exampleapps.yaml"},
+			expectedCode: exitFailure,
+		},
+		{
+			name:         "all hold",
+			args:         []string{"check", "Degraded!=True", "testdata/exampleapps.yaml"},
+			expectedCode: exitOK,
+			expected: `2 resource(s) match Degraded!=True
+`,
+		},
+		{
+			name:         "does not hold",
+			args:         []string{"check", "Degraded=False", "testdata/list.json"},
+			expectedCode: exitFailure,
+			expected: `ExampleApp baz: Degraded=False does not hold: Degraded is True (DeploymentFailed): deployment baz is not available
+`,
+		},
+		{
+			name:         "quiet",
+			args:         []string{"check", "-q", "Degraded=False", "testdata/list.json"},
+			expectedCode: exitFailure,
+		},
+		{
+			name:         "invalid status",
+			args:         []string{"check", "Degraded=Yes", "testdata/list.json"},
+			expectedCode: exitError,
+		},
+		{
+			name:         "invalid requirement",
+			args:         []string{"check", "Degraded=", "testdata/list.json"},
+			expectedCode: exitError,
+		},
+		{
+			name:         "selector",
+			args:         []string{"check", "Degraded, !Upgradeable, reason(Degraded) in (DeploymentFailed,Timeout)", "testdata/list.json"},
+			expectedCode: exitOK,
+		},
+		{
+			name:         "missing expression",
+			args:         []string{"check"},
+			expectedCode: exitError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			code := run(tc.args, strings.NewReader(""), stdout, stderr)
+			if code != tc.expectedCode {
+				t.Fatalf("Unexpected exit code: expected: %d; actual: %d\n%s%s", tc.expectedCode, code, stdout.String(), stderr.String())
+			}
+			if tc.expected != "" && stdout.String() != tc.expected {
+				t.Errorf("Unexpected output:\n%s\nexpected:\n%s", stdout.String(), tc.expected)
+			}
+		})
+	}
+}
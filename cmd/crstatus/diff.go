@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	objectreferencesv1 "github.com/openshift/custom-resource-status/objectreferences/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var diffCommand = &command{
+	name:  "diff",
+	usage: "[flags] BEFORE AFTER",
+	description: `Print the conditions that were added, removed or changed, and the related
+objects that were added or removed, between two manifests of the same resources,
+for example before and after an upgrade. Resources are paired by kind, namespace
+and name. Only one of BEFORE and AFTER can be - to read from stdin. Exits with 0
+when there are no differences, 1 when there are, and 2 on error.`,
+	run: runDiff,
+}
+
+// statusDiff holds the differences between two versions of a resource.
+type statusDiff struct {
+	Object         string               `json:"object"`
+	Before         bool                 `json:"before"`
+	After          bool                 `json:"after"`
+	Conditions     conditionsDiff       `json:"conditions"`
+	RelatedObjects objectReferencesDiff `json:"relatedObjects"`
+	before, after  *resource
+}
+
+type conditionsDiff struct {
+	Added   []conditionsv1.Condition `json:"added,omitempty"`
+	Removed []conditionsv1.Condition `json:"removed,omitempty"`
+	// Transitioned holds the conditions whose status changed.
+	Transitioned []conditionChange `json:"transitioned,omitempty"`
+	// Updated holds the conditions whose reason or message changed, but not
+	// their status.
+	Updated []conditionChange `json:"updated,omitempty"`
+}
+
+type conditionChange struct {
+	Type   conditionsv1.ConditionType `json:"type"`
+	Before conditionsv1.Condition     `json:"before"`
+	After  conditionsv1.Condition     `json:"after"`
+}
+
+type objectReferencesDiff struct {
+	Added   []corev1.ObjectReference `json:"added,omitempty"`
+	Removed []corev1.ObjectReference `json:"removed,omitempty"`
+}
+
+func runDiff(cmd *command, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := cmd.flagSet(stderr)
+	output := flags.String("o", "text", "output format, one of: text, json")
+	if ok, code := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitError
+	}
+	if *output != "text" && *output != "json" {
+		return fail(stderr, fmt.Errorf("unknown output format %q", *output))
+	}
+	if flags.Arg(0) == stdinName && flags.Arg(1) == stdinName {
+		return fail(stderr, fmt.Errorf("BEFORE and AFTER cannot both be read from stdin"))
+	}
+
+	before, err := readResources(flags.Args()[:1], stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	after, err := readResources(flags.Args()[1:], stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	diffs, err := diffResources(before, after)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	if *output == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diffs); err != nil {
+			return fail(stderr, err)
+		}
+	} else {
+		printDiffs(stdout, diffs)
+	}
+
+	for _, diff := range diffs {
+		if !diff.empty() {
+			return exitFailure
+		}
+	}
+	return exitOK
+}
+
+// diffResources pairs the resources by kind, namespace and name, and returns
+// their differences, in the order of before then after. A resource present
+// twice on the same side, or with two conditions of the same type, is an error
+// as it cannot be paired unambiguously.
+func diffResources(before, after []*resource) ([]*statusDiff, error) {
+	diffs := []*statusDiff{}
+	byName := map[string]*statusDiff{}
+	for _, res := range before {
+		if err := checkDuplicateConditions(res); err != nil {
+			return nil, err
+		}
+		if _, ok := byName[res.String()]; ok {
+			return nil, fmt.Errorf("%s: duplicate resource %s", res.source, res)
+		}
+		diff := &statusDiff{Object: res.String(), Before: true, before: res}
+		byName[diff.Object] = diff
+		diffs = append(diffs, diff)
+	}
+	for _, res := range after {
+		if err := checkDuplicateConditions(res); err != nil {
+			return nil, err
+		}
+		diff, ok := byName[res.String()]
+		if ok && diff.After {
+			return nil, fmt.Errorf("%s: duplicate resource %s", res.source, res)
+		}
+		if !ok {
+			diff = &statusDiff{Object: res.String()}
+			byName[diff.Object] = diff
+			diffs = append(diffs, diff)
+		}
+		diff.After = true
+		diff.after = res
+	}
+
+	for _, diff := range diffs {
+		beforeStatus, afterStatus := resourceStatus{}, resourceStatus{}
+		if diff.before != nil {
+			beforeStatus = diff.before.status
+		}
+		if diff.after != nil {
+			afterStatus = diff.after.status
+		}
+		diff.Conditions = diffConditions(beforeStatus.Conditions, afterStatus.Conditions)
+		diff.RelatedObjects = diffObjectReferences(beforeStatus.RelatedObjects, afterStatus.RelatedObjects)
+	}
+	return diffs, nil
+}
+
+func checkDuplicateConditions(res *resource) error {
+	seen := map[conditionsv1.ConditionType]bool{}
+	for _, condition := range res.status.Conditions {
+		if seen[condition.Type] {
+			return fmt.Errorf("%s: %s has duplicate %s conditions", res.source, res, condition.Type)
+		}
+		seen[condition.Type] = true
+	}
+	return nil
+}
+
+func diffConditions(before, after []conditionsv1.Condition) conditionsDiff {
+	diff := conditionsDiff{}
+	for _, condition := range before {
+		if conditionsv1.FindStatusCondition(after, condition.Type) == nil {
+			diff.Removed = append(diff.Removed, condition)
+		}
+	}
+	for _, condition := range after {
+		previous := conditionsv1.FindStatusCondition(before, condition.Type)
+		switch {
+		case previous == nil:
+			diff.Added = append(diff.Added, condition)
+		case previous.Status != condition.Status:
+			diff.Transitioned = append(diff.Transitioned, conditionChange{Type: condition.Type, Before: *previous, After: condition})
+		case previous.Reason != condition.Reason || previous.Message != condition.Message:
+			diff.Updated = append(diff.Updated, conditionChange{Type: condition.Type, Before: *previous, After: condition})
+		}
+	}
+	return diff
+}
+
+func diffObjectReferences(before, after []corev1.ObjectReference) objectReferencesDiff {
+	diff := objectReferencesDiff{}
+	for _, ref := range before {
+		if found, _ := objectreferencesv1.FindObjectReference(after, ref); found == nil {
+			diff.Removed = append(diff.Removed, ref)
+		}
+	}
+	for _, ref := range after {
+		if found, _ := objectreferencesv1.FindObjectReference(before, ref); found == nil {
+			diff.Added = append(diff.Added, ref)
+		}
+	}
+	return diff
+}
+
+func (d *statusDiff) empty() bool {
+	return d.Before && d.After &&
+		len(d.Conditions.Added) == 0 && len(d.Conditions.Removed) == 0 &&
+		len(d.Conditions.Transitioned) == 0 && len(d.Conditions.Updated) == 0 &&
+		len(d.RelatedObjects.Added) == 0 && len(d.RelatedObjects.Removed) == 0
+}
+
+func printDiffs(w io.Writer, diffs []*statusDiff) {
+	for i, diff := range diffs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		switch {
+		case !diff.After:
+			fmt.Fprintf(w, "%s: only in before\n", diff.Object)
+		case !diff.Before:
+			fmt.Fprintf(w, "%s: only in after\n", diff.Object)
+		case diff.empty():
+			fmt.Fprintf(w, "%s: no changes\n", diff.Object)
+			continue
+		default:
+			fmt.Fprintf(w, "%s\n", diff.Object)
+		}
+
+		conditions := diff.Conditions
+		if len(conditions.Added)+len(conditions.Removed)+len(conditions.Transitioned)+len(conditions.Updated) > 0 {
+			fmt.Fprintf(w, "Conditions:\n")
+		}
+		for _, condition := range conditions.Added {
+			fmt.Fprintf(w, "  + %s\n", describeCondition(condition))
+		}
+		for _, condition := range conditions.Removed {
+			fmt.Fprintf(w, "  - %s\n", describeCondition(condition))
+		}
+		for _, change := range conditions.Transitioned {
+			fmt.Fprintf(w, "  ~ %s: %s -> %s\n", change.Type, describeStatus(change.Before), describeStatus(change.After))
+		}
+		for _, change := range conditions.Updated {
+			fmt.Fprintf(w, "  ~ %s: %s -> %s\n", change.Type, describeStatus(change.Before), describeStatus(change.After))
+		}
+
+		refs := diff.RelatedObjects
+		if len(refs.Added)+len(refs.Removed) > 0 {
+			fmt.Fprintf(w, "Related Objects:\n")
+		}
+		for _, ref := range refs.Added {
+			fmt.Fprintf(w, "  + %s\n", describeObjectReference(ref))
+		}
+		for _, ref := range refs.Removed {
+			fmt.Fprintf(w, "  - %s\n", describeObjectReference(ref))
+		}
+	}
+}
+
+// describeCondition returns "TYPE=STATUS (REASON): MESSAGE".
+func describeCondition(condition conditionsv1.Condition) string {
+	return fmt.Sprintf("%s=%s", condition.Type, describeStatus(condition))
+}
+
+// describeStatus returns "STATUS (REASON): MESSAGE".
+func describeStatus(condition conditionsv1.Condition) string {
+	description := string(condition.Status)
+	if condition.Reason != "" {
+		description += fmt.Sprintf(" (%s)", condition.Reason)
+	}
+	if condition.Message != "" {
+		description += fmt.Sprintf(": %q", condition.Message)
+	}
+	return description
+}
+
+func describeObjectReference(ref corev1.ObjectReference) string {
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s %s %s", ref.APIVersion, ref.Kind, ref.Name)
+	}
+	return fmt.Sprintf("%s %s %s/%s", ref.APIVersion, ref.Kind, ref.Namespace, ref.Name)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expected     string
+	}{
+		{
+			name:         "changes",
+			args:         []string{"diff", "testdata/before.yaml", "testdata/after.yaml"},
+			expectedCode: exitFailure,
+			expected: `ExampleApp test-namespace/foo
+Conditions:
+  + Upgradeable=True
+  - Legacy=True
+  ~ Degraded: False (AsExpected) -> True (DeploymentFailed): "deployment foo is not available"
+  ~ Progressing: False (AsExpected): "Deployed version 1.0" -> False (AsExpected): "Deployed version 2.0"
+Related Objects:
+  + v1 Secret test-namespace/foo-credentials
+  - v1 ConfigMap test-namespace/foo-config
+`,
+		},
+		{
+			name:         "no changes",
+			args:         []string{"diff", "testdata/after.yaml", "testdata/after.yaml"},
+			expectedCode: exitOK,
+			expected: `ExampleApp test-namespace/foo: no changes
+`,
+		},
+		{
+			name:         "unpaired resources",
+			args:         []string{"diff", "testdata/list.json", "testdata/after.yaml"},
+			expectedCode: exitFailure,
+			expected: `ExampleApp baz: only in before
+Conditions:
+  - Degraded=True (DeploymentFailed): "deployment baz is not available"
+
+ExampleApp test-namespace/foo: only in after
+Conditions:
+  + Available=True (AsExpected)
+  + Degraded=True (DeploymentFailed): "deployment foo is not available"
+  + Progressing=False (AsExpected): "Deployed version 2.0"
+  + Upgradeable=True
+Related Objects:
+  + apps/v1 Deployment test-namespace/foo
+  + v1 Secret test-namespace/foo-credentials
+`,
+		},
+		{
+			name:         "missing argument",
+			args:         []string{"diff", "testdata/before.yaml"},
+			expectedCode: exitError,
+		},
+		{
+			name:         "stdin for both",
+			args:         []string{"diff", "-", "-"},
+			expectedCode: exitError,
+		},
+		{
+			name:         "duplicates",
+			args:         []string{"diff", "testdata/duplicates.yaml", "testdata/after.yaml"},
+			expectedCode: exitError,
+		},
+		{
+			name:         "unknown output",
+			args:         []string{"diff", "-o", "yaml", "testdata/before.yaml", "testdata/after.yaml"},
+			expectedCode: exitError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			code := run(tc.args, strings.NewReader(""), stdout, stderr)
+			if code != tc.expectedCode {
+				t.Fatalf("Unexpected exit code: expected: %d; actual: %d\n%s%s", tc.expectedCode, code, stdout.String(), stderr.String())
+			}
+			if tc.expected != "" && stdout.String() != tc.expected {
+				t.Errorf("Unexpected output:\n%s\nexpected:\n%s", stdout.String(), tc.expected)
+			}
+		})
+	}
+}
+
+func TestDiffResourcesDuplicates(t *testing.T) {
+	resources, err := readResources([]string{"testdata/duplicates.yaml"}, nil)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	foo, bar := resources[0], resources[1]
+	fooFixed := &resource{source: foo.source, object: foo.object, status: resourceStatus{Conditions: foo.status.Conditions[:1]}}
+
+	testCases := []struct {
+		name          string
+		before        []*resource
+		after         []*resource
+		expectedError string
+	}{
+		{
+			name:          "duplicate conditions",
+			before:        []*resource{foo},
+			after:         []*resource{fooFixed},
+			expectedError: "testdata/duplicates.yaml: ExampleApp test-namespace/foo has duplicate Degraded conditions",
+		},
+		{
+			name:          "duplicate resources before",
+			before:        []*resource{bar, bar},
+			after:         []*resource{bar},
+			expectedError: "testdata/duplicates.yaml: duplicate resource ExampleApp test-namespace/bar",
+		},
+		{
+			name:          "duplicate resources after",
+			before:        []*resource{bar},
+			after:         []*resource{bar, bar},
+			expectedError: "testdata/duplicates.yaml: duplicate resource ExampleApp test-namespace/bar",
+		},
+		{
+			name:          "duplicate resources only in after",
+			before:        []*resource{fooFixed},
+			after:         []*resource{bar, bar},
+			expectedError: "testdata/duplicates.yaml: duplicate resource ExampleApp test-namespace/bar",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := diffResources(tc.before, tc.after)
+			if err == nil || err.Error() != tc.expectedError {
+				t.Errorf("Unexpected error '%v', expected '%v'", err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run([]string{"diff", "-o", "json", "testdata/before.yaml", "testdata/after.yaml"}, nil, stdout, stderr)
+	if code != exitFailure {
+		t.Fatalf("Unexpected exit code: expected: %d; actual: %d\n%s", exitFailure, code, stderr.String())
+	}
+
+	diffs := []statusDiff{}
+	if err := json.Unmarshal(stdout.Bytes(), &diffs); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v\n%s", err, stdout.String())
+	}
+	if len(diffs) != 1 {
+		t.Fatalf("Unexpected number of diffs: expected: 1; actual: %d", len(diffs))
+	}
+	diff := diffs[0]
+	if diff.Object != "ExampleApp test-namespace/foo" || !diff.Before || !diff.After {
+		t.Errorf("Unexpected diff '%+v'", diff)
+	}
+	if len(diff.Conditions.Added) != 1 || diff.Conditions.Added[0].Type != "Upgradeable" {
+		t.Errorf("Unexpected added conditions '%v'", diff.Conditions.Added)
+	}
+	if len(diff.Conditions.Transitioned) != 1 || diff.Conditions.Transitioned[0].After.Status != "True" {
+		t.Errorf("Unexpected transitioned conditions '%v'", diff.Conditions.Transitioned)
+	}
+	if len(diff.RelatedObjects.Removed) != 1 || diff.RelatedObjects.Removed[0].Name != "foo-config" {
+		t.Errorf("Unexpected removed related objects '%v'", diff.RelatedObjects.Removed)
+	}
+}
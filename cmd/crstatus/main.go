@@ -0,0 +1,112 @@
+// Command crstatus inspects the status of custom resources saved in manifests,
+// for example in must-gather dumps, without access to a cluster.
+//
+// Usage:
+//
+//	crstatus <command> [flags] [FILE...]
+//
+// Manifests are read from the provided files, or from stdin when no file or
+// "-" is provided. They can be YAML or JSON, single documents, multi-document
+// streams or List objects.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Exit codes, following the grep conventions so scripts can tell a negative
+// answer from a failure.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitError   = 2
+)
+
+// now returns the time ages are computed from. Tests override it.
+var now = time.Now
+
+// command is a crstatus subcommand.
+type command struct {
+	name        string
+	usage       string
+	description string
+	// run runs the command with its arguments and returns the exit code.
+	run func(cmd *command, args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+// flagSet returns an empty flag set printing the usage of the command.
+func (c *command) flagSet(stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet("crstatus "+c.name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: crstatus %s %s\n\n%s\n", c.name, c.usage, c.description)
+		hasFlags := false
+		flags.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintf(stderr, "\nFlags:\n")
+			flags.PrintDefaults()
+		}
+	}
+	return flags
+}
+
+// parseFlags parses args into flags. When parsing stops the command, it
+// returns false and the exit code.
+func parseFlags(flags *flag.FlagSet, args []string) (bool, int) {
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return false, exitOK
+		}
+		return false, exitError
+	}
+	return true, exitOK
+}
+
+var commands = []*command{
+	showCommand,
+	checkCommand,
+	diffCommand,
+	schemaCommand,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return exitError
+		}
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(cmd, args[1:], stdin, stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "crstatus: unknown command %q\n\n", args[0])
+	printUsage(stderr)
+	return exitError
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: crstatus <command> [flags] [FILE...]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintf(w, "\nRun 'crstatus <command> -h' for the flags of a command.\n")
+}
+
+// fail prints err to stderr and returns exitError.
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintf(stderr, "crstatus: %v\n", err)
+	return exitError
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func init() {
+	now = func() time.Time {
+		return time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	if code := run([]string{"frobnicate"}, nil, &bytes.Buffer{}, stderr); code != exitError {
+		t.Errorf("Unexpected exit code: expected: %d; actual: %d", exitError, code)
+	}
+	if !strings.Contains(stderr.String(), `unknown command "frobnicate"`) {
+		t.Errorf("Unexpected error output '%v'", stderr.String())
+	}
+}
+
+func readTestdata(t *testing.T, file string) string {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	return string(data)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// stdinName is the file name reading from stdin.
+const stdinName = "-"
+
+// resource is a custom resource read from a manifest.
+type resource struct {
+	// source is the file the resource was read from.
+	source string
+	object *unstructured.Unstructured
+	status resourceStatus
+}
+
+// resourceStatus holds the fields of the status managed with this library.
+type resourceStatus struct {
+	Conditions     []conditionsv1.Condition `json:"conditions,omitempty"`
+	RelatedObjects []corev1.ObjectReference `json:"relatedObjects,omitempty"`
+}
+
+// String returns the kind, namespace and name of the resource.
+func (r *resource) String() string {
+	if r.object.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", r.object.GetKind(), r.object.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", r.object.GetKind(), r.object.GetNamespace(), r.object.GetName())
+}
+
+// readResources reads the resources from files, or from stdin when files is
+// empty or for the "-" file.
+func readResources(files []string, stdin io.Reader) ([]*resource, error) {
+	if len(files) == 0 {
+		files = []string{stdinName}
+	}
+	resources := []*resource{}
+	for _, file := range files {
+		fileResources, err := readFile(file, stdin)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, fileResources...)
+	}
+	return resources, nil
+}
+
+func readFile(file string, stdin io.Reader) ([]*resource, error) {
+	r := stdin
+	if file != stdinName {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	resources, err := decodeResources(file, r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	return resources, nil
+}
+
+// decodeResources decodes the resources from a single document, a stream of
+// YAML or JSON documents, or List objects.
+func decodeResources(source string, r io.Reader) ([]*resource, error) {
+	resources := []*resource{}
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if err == io.EOF {
+				return resources, nil
+			}
+			return nil, err
+		}
+		if len(object.Object) == 0 {
+			continue
+		}
+
+		objects := []*unstructured.Unstructured{object}
+		if object.IsList() {
+			objects = objects[:0]
+			err := object.EachListItem(func(item runtime.Object) error {
+				objects = append(objects, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, o := range objects {
+			res, err := newResource(source, o)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, res)
+		}
+	}
+}
+
+func newResource(source string, object *unstructured.Unstructured) (*resource, error) {
+	res := &resource{source: source, object: object}
+	status, found, err := unstructured.NestedMap(object.Object, "status")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", res, err)
+	}
+	if !found {
+		return res, nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(status, &res.status); err != nil {
+		return nil, fmt.Errorf("%s: %v", res, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	conditionsschema "github.com/openshift/custom-resource-status/conditions/v1/schema"
+	objectreferencesschema "github.com/openshift/custom-resource-status/objectreferences/v1/schema"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var schemaCommand = &command{
+	name:  "schema",
+	usage: "[flags] [conditions|relatedObjects|status]",
+	description: `Print the apiextensions.k8s.io/v1 structural schema of the conditions, of the
+related objects, or of a status with both (the default), to embed or merge into
+the openAPIV3Schema of a CustomResourceDefinition. With -rules, the items of
+the conditions schema also carry the x-kubernetes-validations CEL rules
+enforcing that lastTransitionTime is set.`,
+	run: runSchema,
+}
+
+func runSchema(cmd *command, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := cmd.flagSet(stderr)
+	output := flags.String("o", "yaml", "output format, one of: yaml, json")
+	rules := flags.Bool("rules", false, "add the CEL validation rules to the conditions schema")
+	if ok, code := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	conditions := conditionsschema.Conditions()
+	if *rules {
+		conditions.Items.Schema.XValidations = conditionsschema.ValidationRules()
+	}
+
+	var props apiextensionsv1.JSONSchemaProps
+	switch field := flags.Arg(0); field {
+	case "conditions":
+		props = conditions
+	case "relatedObjects":
+		props = objectreferencesschema.ObjectReferences()
+	case "status", "":
+		props = apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"conditions":     conditions,
+				"relatedObjects": objectreferencesschema.ObjectReferences(),
+			},
+		}
+	default:
+		return fail(stderr, fmt.Errorf("unknown field %q", field))
+	}
+
+	var data []byte
+	var err error
+	switch *output {
+	case "yaml":
+		data, err = yaml.Marshal(props)
+	case "json":
+		data, err = json.MarshalIndent(props, "", "  ")
+		data = append(data, '\n')
+	default:
+		return fail(stderr, fmt.Errorf("unknown output format %q", *output))
+	}
+	if err != nil {
+		return fail(stderr, err)
+	}
+	stdout.Write(data)
+	return exitOK
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	conditionsschema "github.com/openshift/custom-resource-status/conditions/v1/schema"
+	objectreferencesschema "github.com/openshift/custom-resource-status/objectreferences/v1/schema"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/yaml"
+)
+
+func TestSchema(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expected     *apiextensionsv1.JSONSchemaProps
+	}{
+		{
+			name:         "conditions",
+			args:         []string{"schema", "conditions"},
+			expectedCode: exitOK,
+			expected:     schemaPtr(conditionsschema.Conditions()),
+		},
+		{
+			name:         "conditions with rules",
+			args:         []string{"schema", "-rules", "conditions"},
+			expectedCode: exitOK,
+			expected:     conditionsWithRules(),
+		},
+		{
+			name:         "related objects as json",
+			args:         []string{"schema", "-o", "json", "relatedObjects"},
+			expectedCode: exitOK,
+			expected:     schemaPtr(objectreferencesschema.ObjectReferences()),
+		},
+		{
+			name:         "status by default",
+			args:         []string{"schema"},
+			expectedCode: exitOK,
+			expected: &apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"conditions":     conditionsschema.Conditions(),
+					"relatedObjects": objectreferencesschema.ObjectReferences(),
+				},
+			},
+		},
+		{
+			name:         "unknown field",
+			args:         []string{"schema", "spec"},
+			expectedCode: exitError,
+		},
+		{
+			name:         "unknown output",
+			args:         []string{"schema", "-o", "table"},
+			expectedCode: exitError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			code := run(tc.args, nil, stdout, stderr)
+			if code != tc.expectedCode {
+				t.Fatalf("Unexpected exit code: expected: %d; actual: %d\n%s", tc.expectedCode, code, stderr.String())
+			}
+			if tc.expected == nil {
+				return
+			}
+			// JSON is valid YAML, so both outputs are decoded the same way.
+			actual := &apiextensionsv1.JSONSchemaProps{}
+			if err := yaml.Unmarshal(stdout.Bytes(), actual); err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v\n%s", err, stdout.String())
+			}
+			if !equality.Semantic.DeepEqual(actual, tc.expected) {
+				t.Errorf("Unexpected schema:\n%s", stdout.String())
+			}
+		})
+	}
+}
+
+func schemaPtr(props apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps {
+	return &props
+}
+
+func conditionsWithRules() *apiextensionsv1.JSONSchemaProps {
+	props := conditionsschema.Conditions()
+	props.Items.Schema.XValidations = conditionsschema.ValidationRules()
+	return &props
+}
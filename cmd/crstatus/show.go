@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/openshift/custom-resource-status/conditions/v1/printer"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var showCommand = &command{
+	name:        "show",
+	usage:       "[flags] [FILE...]",
+	description: "Print the conditions and related objects of the resources in the manifests.",
+	run:         runShow,
+}
+
+func runShow(cmd *command, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := cmd.flagSet(stderr)
+	output := flags.String("o", "table", "output format of the conditions, one of: table, describe")
+	maxMessageWidth := flags.Int("max-message-width", 0, "maximum width of the messages, 0 for unlimited")
+	wrap := flags.Bool("wrap", false, "wrap messages longer than -max-message-width instead of truncating them")
+	if ok, code := parseFlags(flags, args); !ok {
+		return code
+	}
+
+	var print func(io.Writer, []conditionsv1.Condition, printer.Options) error
+	switch *output {
+	case "table":
+		print = printer.PrintTable
+	case "describe":
+		print = printer.PrintDescribe
+	default:
+		return fail(stderr, fmt.Errorf("unknown output format %q", *output))
+	}
+
+	resources, err := readResources(flags.Args(), stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	options := printer.Options{
+		Now:             now(),
+		MaxMessageWidth: *maxMessageWidth,
+		WrapMessages:    *wrap,
+	}
+	for i, res := range resources {
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		fmt.Fprintf(stdout, "%s (%s)\n", res, res.source)
+
+		fmt.Fprintf(stdout, "\nConditions:\n")
+		if len(res.status.Conditions) == 0 {
+			fmt.Fprintf(stdout, "<none>\n")
+		} else if err := print(stdout, res.status.Conditions, options); err != nil {
+			return fail(stderr, err)
+		}
+
+		fmt.Fprintf(stdout, "\nRelated Objects:\n")
+		if len(res.status.RelatedObjects) == 0 {
+			fmt.Fprintf(stdout, "<none>\n")
+		} else if err := printObjectReferences(stdout, res.status.RelatedObjects); err != nil {
+			return fail(stderr, err)
+		}
+	}
+	return exitOK
+}
+
+// printObjectReferences writes refs to w as an aligned table.
+func printObjectReferences(w io.Writer, refs []corev1.ObjectReference) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "APIVERSION\tKIND\tNAMESPACE\tNAME")
+	for _, ref := range refs {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = "<none>"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", ref.APIVersion, ref.Kind, namespace, ref.Name)
+	}
+	return tw.Flush()
+}
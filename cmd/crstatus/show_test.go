@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestShow(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		stdin        string
+		expectedCode int
+		expected     string
+	}{
+		{
+			name:         "multi-document yaml",
+			args:         []string{"show", "testdata/exampleapps.yaml"},
+			expectedCode: exitOK,
+			expected: `ExampleApp test-namespace/foo (testdata/exampleapps.yaml)
+
+Conditions:
+TYPE       STATUS  REASON      AGE   MESSAGE
+Available  True    AsExpected  5m    All components are available
+Degraded   False   AsExpected  120m
+
+Related Objects:
+APIVERSION  KIND        NAMESPACE       NAME
+apps/v1     Deployment  test-namespace  foo
+
+ExampleApp test-namespace/bar (testdata/exampleapps.yaml)
+
+Conditions:
+<none>
+
+Related Objects:
+<none>
+`,
+		},
+		{
+			name:         "json list from stdin",
+			args:         []string{"show", "-o", "describe"},
+			stdin:        readTestdata(t, "testdata/list.json"),
+			expectedCode: exitOK,
+			expected: `ExampleApp baz (-)
+
+Conditions:
+Type:                  Degraded
+Status:                True
+Reason:                DeploymentFailed
+Message:               deployment baz is not available
+Last Transition Time:  2021-01-01T11:00:00Z (60m ago)
+Last Heartbeat Time:   2021-01-01T11:59:00Z (60s ago)
+
+Related Objects:
+<none>
+`,
+		},
+		{
+			name:         "truncated messages",
+			args:         []string{"show", "-max-message-width", "10", "testdata/list.json"},
+			expectedCode: exitOK,
+			expected: `ExampleApp baz (testdata/list.json)
+
+Conditions:
+TYPE      STATUS  REASON            AGE  MESSAGE
+Degraded  True    DeploymentFailed  60m  deploym...
+
+Related Objects:
+<none>
+`,
+		},
+		{
+			name:         "unknown output",
+			args:         []string{"show", "-o", "wide", "testdata/list.json"},
+			expectedCode: exitError,
+		},
+		{
+			name:         "missing file",
+			args:         []string{"show", "testdata/missing.yaml"},
+			expectedCode: exitError,
+		},
+		{
+			name:         "invalid manifest",
+			args:         []string{"show"},
+			stdin:        "status: [",
+			expectedCode: exitError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			code := run(tc.args, strings.NewReader(tc.stdin), stdout, stderr)
+			if code != tc.expectedCode {
+				t.Fatalf("Unexpected exit code: expected: %d; actual: %d\n%s", tc.expectedCode, code, stderr.String())
+			}
+			if stdout.String() != tc.expected {
+				t.Errorf("Unexpected output:\n%s\nexpected:\n%s", stdout.String(), tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Package audit records condition transitions as JSON Lines, one JSON object
+// per transition, and replays such records to reconstruct the condition
+// timeline of an object.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	objectreferencesv1 "github.com/openshift/custom-resource-status/objectreferences/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Record is a single condition transition.
+type Record struct {
+	// Timestamp is the time the transition was recorded.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Object is the object owning the condition.
+	Object corev1.ObjectReference `json:"object"`
+
+	Type conditionsv1.ConditionType `json:"type"`
+
+	// From is the status before the transition, empty when the condition was added.
+	From corev1.ConditionStatus `json:"from,omitempty"`
+
+	To corev1.ConditionStatus `json:"to"`
+
+	Reason string `json:"reason,omitempty"`
+
+	Message string `json:"message,omitempty"`
+}
+
+// Writer is a conditionsv1.TransitionHandler appending a Record to an
+// io.Writer for every transition that changed the condition. Heartbeat-only
+// updates are not recorded.
+type Writer struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+	err     error
+	now     func() time.Time
+}
+
+var _ conditionsv1.TransitionHandler = &Writer{}
+
+// NewWriter returns a Writer appending records to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		encoder: json.NewEncoder(w),
+		now:     time.Now,
+	}
+}
+
+// OnTransition appends a Record for transition. Once writing a record failed,
+// no more records are written and the error is returned by Err.
+func (w *Writer) OnTransition(object runtime.Object, transition conditionsv1.Transition) {
+	if !transition.Changed() {
+		return
+	}
+
+	record := Record{
+		Object:  objectreferencesv1.ObjectReferenceFor(object),
+		Type:    transition.Current.Type,
+		To:      transition.Current.Status,
+		Reason:  transition.Current.Reason,
+		Message: transition.Current.Message,
+	}
+	if transition.Previous != nil {
+		record.From = transition.Previous.Status
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.err != nil {
+		return
+	}
+	record.Timestamp = w.now()
+	w.err = w.encoder.Encode(record)
+}
+
+// Err returns the first error that occurred while writing records.
+func (w *Writer) Err() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.err
+}
+
+// ReadRecords reads all the records from r. Empty lines are ignored.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	records := []Record{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := Record{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Timeline is the ordered list of records of a single object.
+type Timeline struct {
+	Records []Record
+}
+
+// Replay reads the records from r and returns the timeline of object.
+// Records match object when their kind, namespace and name are equal, and
+// their apiVersion and UID are equal when set on both sides.
+func Replay(r io.Reader, object corev1.ObjectReference) (*Timeline, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	timeline := &Timeline{Records: []Record{}}
+	for _, record := range records {
+		if matches(record.Object, object) {
+			timeline.Records = append(timeline.Records, record)
+		}
+	}
+	return timeline, nil
+}
+
+// Conditions returns the conditions of the object after the last record.
+func (t *Timeline) Conditions() []conditionsv1.Condition {
+	return t.apply(func(Record) bool { return true })
+}
+
+// ConditionsAt returns the conditions of the object as they were at the
+// provided time.
+func (t *Timeline) ConditionsAt(at time.Time) []conditionsv1.Condition {
+	return t.apply(func(record Record) bool { return !record.Timestamp.After(at) })
+}
+
+// History returns the records of the provided conditionType.
+func (t *Timeline) History(conditionType conditionsv1.ConditionType) []Record {
+	records := []Record{}
+	for _, record := range t.Records {
+		if record.Type == conditionType {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func (t *Timeline) apply(include func(Record) bool) []conditionsv1.Condition {
+	conditions := []conditionsv1.Condition{}
+	for _, record := range t.Records {
+		if !include(record) {
+			break
+		}
+		condition := conditionsv1.FindStatusCondition(conditions, record.Type)
+		if condition == nil {
+			conditions = append(conditions, conditionsv1.Condition{Type: record.Type})
+			condition = &conditions[len(conditions)-1]
+		}
+		if condition.Status != record.To {
+			condition.LastTransitionTime = metav1.NewTime(record.Timestamp)
+		}
+		condition.Status = record.To
+		condition.Reason = record.Reason
+		condition.Message = record.Message
+		condition.LastHeartbeatTime = metav1.NewTime(record.Timestamp)
+	}
+	return conditions
+}
+
+func matches(got, expected corev1.ObjectReference) bool {
+	if got.Kind != expected.Kind || got.Namespace != expected.Namespace || got.Name != expected.Name {
+		return false
+	}
+	if got.APIVersion != "" && expected.APIVersion != "" && got.APIVersion != expected.APIVersion {
+		return false
+	}
+	if got.UID != "" && expected.UID != "" && got.UID != expected.UID {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriterAndReplay(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewWriter(buf)
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	writer.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+
+	foo := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "foo", UID: "fooid"},
+	}
+	bar := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "bar"}}
+
+	fooConditions := []conditionsv1.Condition{}
+	barConditions := []conditionsv1.Condition{}
+	set := func(object *corev1.ConfigMap, conditions *[]conditionsv1.Condition, status corev1.ConditionStatus, reason string) {
+		conditionsv1.SetStatusConditionAndNotify(object, conditions, conditionsv1.Condition{
+			Type:    conditionsv1.ConditionDegraded,
+			Status:  status,
+			Reason:  reason,
+			Message: "Degraded condition " + reason,
+		}, writer)
+	}
+	set(foo, &fooConditions, corev1.ConditionFalse, "AsExpected")  // 00:01
+	set(bar, &barConditions, corev1.ConditionTrue, "Failing")      // 00:02
+	set(foo, &fooConditions, corev1.ConditionFalse, "AsExpected")  // heartbeat, not recorded
+	set(foo, &fooConditions, corev1.ConditionTrue, "Failing")      // 00:03
+	set(foo, &fooConditions, corev1.ConditionTrue, "StillFailing") // 00:04
+	if err := writer.Err(); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Unexpected number of lines: expected: 4; actual: %d\n%s", len(lines), buf.String())
+	}
+	expectedLine := `{"timestamp":"2021-01-01T00:01:00Z","object":{"kind":"ConfigMap","namespace":"test-namespace","name":"foo","uid":"fooid","apiVersion":"v1"},"type":"Degraded","to":"False","reason":"AsExpected","message":"Degraded condition AsExpected"}`
+	if lines[0] != expectedLine {
+		t.Errorf("Unexpected line '%v', expected '%v'", lines[0], expectedLine)
+	}
+
+	timeline, err := Replay(strings.NewReader(buf.String()), corev1.ObjectReference{Kind: "ConfigMap", Namespace: "test-namespace", Name: "foo"})
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	if len(timeline.Records) != 3 {
+		t.Fatalf("Unexpected number of records: expected: 3; actual: %d", len(timeline.Records))
+	}
+	history := timeline.History(conditionsv1.ConditionDegraded)
+	expectedFrom := []corev1.ConditionStatus{"", corev1.ConditionFalse, corev1.ConditionTrue}
+	for i := range expectedFrom {
+		if history[i].From != expectedFrom[i] {
+			t.Errorf("Unexpected from '%v', expected '%v'", history[i].From, expectedFrom[i])
+		}
+	}
+
+	conditions := timeline.Conditions()
+	degraded := conditionsv1.FindStatusCondition(conditions, conditionsv1.ConditionDegraded)
+	if degraded == nil || degraded.Status != corev1.ConditionTrue || degraded.Reason != "StillFailing" {
+		t.Fatalf("Unexpected conditions '%v'", conditions)
+	}
+	if expected := start.Add(3 * time.Minute); !degraded.LastTransitionTime.Time.Equal(expected) {
+		t.Errorf("Unexpected lastTransitionTime '%v', expected '%v'", degraded.LastTransitionTime, expected)
+	}
+
+	conditions = timeline.ConditionsAt(start.Add(2 * time.Minute))
+	degraded = conditionsv1.FindStatusCondition(conditions, conditionsv1.ConditionDegraded)
+	if degraded == nil || degraded.Status != corev1.ConditionFalse {
+		t.Errorf("Unexpected conditions '%v'", conditions)
+	}
+	if conditions := timeline.ConditionsAt(start); len(conditions) != 0 {
+		t.Errorf("Unexpected conditions '%v', expected none", conditions)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriterError(t *testing.T) {
+	writer := NewWriter(failingWriter{})
+	conditions := []conditionsv1.Condition{}
+	conditionsv1.SetStatusConditionAndNotify(&corev1.ConfigMap{}, &conditions, conditionsv1.Condition{
+		Type:   conditionsv1.ConditionAvailable,
+		Status: corev1.ConditionTrue,
+	}, writer)
+	if writer.Err() == nil {
+		t.Error("Expected an error writing the record")
+	}
+}
+
+func TestReadRecordsError(t *testing.T) {
+	_, err := ReadRecords(strings.NewReader("{\"type\":\"Available\",\"to\":\"True\"}\n\nnot json\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("Unexpected error '%v', expected an error on line 3", err)
+	}
+}
@@ -112,3 +112,13 @@ func IsStatusConditionPresentAndEqual(conditions []Condition, conditionType Cond
 	}
 	return false
 }
+
+// ContainsStatus returns true when statuses contains status.
+func ContainsStatus(statuses []corev1.ConditionStatus, status corev1.ConditionStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
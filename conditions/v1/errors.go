@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReasonReconcileError is the reason of the Degraded condition set by
+// FromError for errors that are not a *ConditionError.
+const ReasonReconcileError = "ReconcileError"
+
+// maxMessageLength is the maximum length of the message of a condition, as
+// validated by the MaxLength marker of Condition.Message.
+const maxMessageLength = 32768
+
+// ConditionError is an error that maps to a condition through FromError.
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+// +protobuf=false
+type ConditionError struct {
+	// Type is the condition type the error is reported on.
+	Type ConditionType
+
+	// Reason is the one-word CamelCase reason of the condition.
+	Reason string
+
+	// Polarity determines the abnormal status the condition is set to:
+	// `True` for PolarityNegative, `False` otherwise.
+	Polarity Polarity
+
+	// Err is the wrapped error.
+	Err error
+}
+
+// NewConditionError returns an error reported on conditionType with reason,
+// using the polarity returned by PolarityOf.
+func NewConditionError(conditionType ConditionType, reason string, err error) *ConditionError {
+	return &ConditionError{Type: conditionType, Reason: reason, Polarity: PolarityOf(conditionType), Err: err}
+}
+
+// DegradedError returns an error reported as ConditionDegraded `True` with reason.
+func DegradedError(reason string, err error) *ConditionError {
+	return NewConditionError(ConditionDegraded, reason, err)
+}
+
+// UnavailableError returns an error reported as ConditionAvailable `False` with reason.
+func UnavailableError(reason string, err error) *ConditionError {
+	return NewConditionError(ConditionAvailable, reason, err)
+}
+
+// Error returns the message of the wrapped error.
+func (e *ConditionError) Error() string {
+	if e.Err == nil {
+		return e.Reason
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error, for errors.Is and errors.As.
+func (e *ConditionError) Unwrap() error {
+	return e.Err
+}
+
+// Condition returns the condition the error is reported as, with the message
+// of the error truncated to the maximum length of a message.
+func (e *ConditionError) Condition() Condition {
+	status := corev1.ConditionFalse
+	if e.Polarity == PolarityNegative {
+		status = corev1.ConditionTrue
+	}
+	return Condition{
+		Type:    e.Type,
+		Status:  status,
+		Reason:  e.Reason,
+		Message: truncateMessage(e.Error()),
+	}
+}
+
+// FromError sets the condition err is reported as in conditions with
+// SetStatusCondition. The first *ConditionError in the chain of err
+// determines the condition, and other errors are reported as
+// ConditionDegraded `True` with ReasonReconcileError. The message is the
+// message of err, including the context added by wrapping errors, truncated
+// to the maximum length of a message. A nil err leaves conditions untouched.
+// The return value indicates if this resulted in any changes *other than* LastHeartbeatTime.
+func FromError(conditions *[]Condition, err error) bool {
+	if err == nil {
+		return false
+	}
+	condition := Condition{
+		Type:   ConditionDegraded,
+		Status: corev1.ConditionTrue,
+		Reason: ReasonReconcileError,
+	}
+	var conditionErr *ConditionError
+	if errors.As(err, &conditionErr) {
+		condition = conditionErr.Condition()
+	}
+	condition.Message = truncateMessage(err.Error())
+	return SetStatusCondition(conditions, condition)
+}
+
+// truncateMessage truncates message to maxMessageLength characters, ending
+// it with "..." when truncated.
+func truncateMessage(message string) string {
+	runes := []rune(message)
+	if len(runes) <= maxMessageLength {
+		return message
+	}
+	return string(append(runes[:maxMessageLength-3], []rune("...")...))
+}
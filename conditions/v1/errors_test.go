@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var errTimeout = errors.New("timed out waiting for pods")
+
+func TestFromError(t *testing.T) {
+	testCases := []struct {
+		name              string
+		err               error
+		expectedType      ConditionType
+		expectedStatus    corev1.ConditionStatus
+		expectedReason    string
+		expectedMessage   string
+		expectedUnchanged bool
+	}{
+		{
+			name:              "nil",
+			err:               nil,
+			expectedUnchanged: true,
+		},
+		{
+			name:            "unwrapped",
+			err:             errTimeout,
+			expectedType:    ConditionDegraded,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  ReasonReconcileError,
+			expectedMessage: "timed out waiting for pods",
+		},
+		{
+			name:            "degraded",
+			err:             DegradedError("PodsNotReady", errTimeout),
+			expectedType:    ConditionDegraded,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "PodsNotReady",
+			expectedMessage: "timed out waiting for pods",
+		},
+		{
+			name:            "unavailable",
+			err:             UnavailableError("NoEndpoints", errors.New("no endpoints")),
+			expectedType:    ConditionAvailable,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "NoEndpoints",
+			expectedMessage: "no endpoints",
+		},
+		{
+			name:            "custom type",
+			err:             NewConditionError("example.com/Synced", "SyncFailed", errTimeout),
+			expectedType:    "example.com/Synced",
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "SyncFailed",
+			expectedMessage: "timed out waiting for pods",
+		},
+		{
+			name:            "wrapped",
+			err:             fmt.Errorf("deploying operand: %w", DegradedError("PodsNotReady", errTimeout)),
+			expectedType:    ConditionDegraded,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "PodsNotReady",
+			expectedMessage: "deploying operand: timed out waiting for pods",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := []Condition{}
+			changed := FromError(&conditions, tc.err)
+			if changed == tc.expectedUnchanged {
+				t.Errorf("Unexpected changed '%v', expected '%v'", changed, !tc.expectedUnchanged)
+			}
+			if tc.expectedUnchanged {
+				if len(conditions) != 0 {
+					t.Errorf("Unexpected conditions '%v', expected none", conditions)
+				}
+				return
+			}
+
+			condition := FindStatusCondition(conditions, tc.expectedType)
+			if condition == nil {
+				t.Fatalf("Unexpected conditions '%v', expected a %s condition", conditions, tc.expectedType)
+			}
+			if condition.Status != tc.expectedStatus {
+				t.Errorf("Unexpected status '%v', expected '%v'", condition.Status, tc.expectedStatus)
+			}
+			if condition.Reason != tc.expectedReason {
+				t.Errorf("Unexpected reason '%v', expected '%v'", condition.Reason, tc.expectedReason)
+			}
+			if condition.Message != tc.expectedMessage {
+				t.Errorf("Unexpected message '%v', expected '%v'", condition.Message, tc.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestFromErrorTruncation(t *testing.T) {
+	conditions := []Condition{}
+	FromError(&conditions, fmt.Errorf("deploying operand: %w", DegradedError("PodsNotReady", errors.New(strings.Repeat("é", 2*maxMessageLength)))))
+
+	message := []rune(FindStatusCondition(conditions, ConditionDegraded).Message)
+	if len(message) != maxMessageLength || !strings.HasSuffix(string(message), "é...") {
+		t.Errorf("Unexpected message length '%v', expected '%v' ending with '...'", len(message), maxMessageLength)
+	}
+}
+
+func TestConditionErrorChain(t *testing.T) {
+	err := fmt.Errorf("reconciling: %w", DegradedError("PodsNotReady", errTimeout))
+
+	if !errors.Is(err, errTimeout) {
+		t.Errorf("Unexpected errors.Is 'false', expected 'true'")
+	}
+	var conditionErr *ConditionError
+	if !errors.As(err, &conditionErr) {
+		t.Fatalf("Unexpected errors.As 'false', expected 'true'")
+	}
+	if conditionErr.Reason != "PodsNotReady" || conditionErr.Polarity != PolarityNegative {
+		t.Errorf("Unexpected error '%#v', expected the PodsNotReady reason and negative polarity", conditionErr)
+	}
+	if message := DegradedError("PodsNotReady", nil).Error(); message != "PodsNotReady" {
+		t.Errorf("Unexpected message '%v', expected 'PodsNotReady'", message)
+	}
+}
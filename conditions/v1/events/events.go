@@ -0,0 +1,87 @@
+// Package events emits Kubernetes Events for condition transitions reported
+// by the notifying setters of conditions/v1.
+package events
+
+import (
+	"fmt"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// EventRecorder is the subset of k8s.io/client-go/tools/record.EventRecorder
+// used to emit events, so any record.EventRecorder can be passed in.
+type EventRecorder interface {
+	Event(object runtime.Object, eventtype, reason, message string)
+}
+
+// Options configures a Recorder.
+type Options struct {
+	// SuppressMessageOnlyChanges skips events for transitions where only the
+	// message of the condition changed.
+	SuppressMessageOnlyChanges bool
+
+	// Polarities overrides the polarity of condition types. Types not present
+	// fall back to conditionsv1.PolarityOf.
+	Polarities map[conditionsv1.ConditionType]conditionsv1.Polarity
+}
+
+// Recorder is a conditionsv1.TransitionHandler emitting an Event for every
+// transition that changed the condition. Events are of type `Warning` when the
+// new status is abnormal for the polarity of the condition type, and of type
+// `Normal` otherwise.
+type Recorder struct {
+	recorder EventRecorder
+	options  Options
+}
+
+var _ conditionsv1.TransitionHandler = &Recorder{}
+
+// NewRecorder returns a Recorder emitting events through recorder.
+func NewRecorder(recorder EventRecorder, options Options) *Recorder {
+	return &Recorder{
+		recorder: recorder,
+		options:  options,
+	}
+}
+
+// OnTransition emits an Event for object when transition changed the condition.
+func (r *Recorder) OnTransition(object runtime.Object, transition conditionsv1.Transition) {
+	if !transition.Changed() {
+		return
+	}
+	if r.options.SuppressMessageOnlyChanges && transition.MessageOnly() {
+		return
+	}
+
+	condition := transition.Current
+	eventType := corev1.EventTypeNormal
+	if r.polarityOf(condition.Type).IsAbnormal(condition.Status) {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recorder.Event(object, eventType, eventReason(condition), eventMessage(condition))
+}
+
+func (r *Recorder) polarityOf(conditionType conditionsv1.ConditionType) conditionsv1.Polarity {
+	if polarity, ok := r.options.Polarities[conditionType]; ok {
+		return polarity
+	}
+	return conditionsv1.PolarityOf(conditionType)
+}
+
+// eventReason returns the reason of the condition, or its type and status
+// (ie. "DegradedTrue") when the condition has no reason.
+func eventReason(condition conditionsv1.Condition) string {
+	if condition.Reason != "" {
+		return condition.Reason
+	}
+	return string(condition.Type) + string(condition.Status)
+}
+
+func eventMessage(condition conditionsv1.Condition) string {
+	if condition.Message == "" {
+		return fmt.Sprintf("condition %s is %s", condition.Type, condition.Status)
+	}
+	return fmt.Sprintf("condition %s is %s: %s", condition.Type, condition.Status, condition.Message)
+}
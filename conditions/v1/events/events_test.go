@@ -0,0 +1,143 @@
+package events
+
+import (
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type event struct {
+	eventType string
+	reason    string
+	message   string
+}
+
+type fakeRecorder struct {
+	events []event
+}
+
+func (f *fakeRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	f.events = append(f.events, event{eventType: eventtype, reason: reason, message: message})
+}
+
+func TestRecorder(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         Options
+		testCondition   conditionsv1.Condition
+		startConditions []conditionsv1.Condition
+		expectedEvents  []event
+	}{
+		{
+			name: "added available",
+			testCondition: conditionsv1.Condition{
+				Type:    conditionsv1.ConditionAvailable,
+				Status:  corev1.ConditionTrue,
+				Reason:  "AsExpected",
+				Message: "All is well",
+			},
+			expectedEvents: []event{
+				{eventType: corev1.EventTypeNormal, reason: "AsExpected", message: "condition Available is True: All is well"},
+			},
+		},
+		{
+			name: "degraded true is a warning",
+			testCondition: conditionsv1.Condition{
+				Type:   conditionsv1.ConditionDegraded,
+				Status: corev1.ConditionTrue,
+				Reason: "Failing",
+			},
+			startConditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, Reason: "AsExpected"},
+			},
+			expectedEvents: []event{
+				{eventType: corev1.EventTypeWarning, reason: "Failing", message: "condition Degraded is True"},
+			},
+		},
+		{
+			name: "available false is a warning",
+			testCondition: conditionsv1.Condition{
+				Type:   conditionsv1.ConditionAvailable,
+				Status: corev1.ConditionFalse,
+			},
+			expectedEvents: []event{
+				{eventType: corev1.EventTypeWarning, reason: "AvailableFalse", message: "condition Available is False"},
+			},
+		},
+		{
+			name: "polarity override",
+			options: Options{
+				Polarities: map[conditionsv1.ConditionType]conditionsv1.Polarity{
+					"Paused": conditionsv1.PolarityNegative,
+				},
+			},
+			testCondition: conditionsv1.Condition{
+				Type:   "Paused",
+				Status: corev1.ConditionTrue,
+				Reason: "UserRequested",
+			},
+			expectedEvents: []event{
+				{eventType: corev1.EventTypeWarning, reason: "UserRequested", message: "condition Paused is True"},
+			},
+		},
+		{
+			name: "heartbeat only",
+			testCondition: conditionsv1.Condition{
+				Type:   conditionsv1.ConditionDegraded,
+				Status: corev1.ConditionFalse,
+				Reason: "AsExpected",
+			},
+			startConditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, Reason: "AsExpected"},
+			},
+		},
+		{
+			name: "message only",
+			testCondition: conditionsv1.Condition{
+				Type:    conditionsv1.ConditionDegraded,
+				Status:  corev1.ConditionFalse,
+				Reason:  "AsExpected",
+				Message: "new message",
+			},
+			startConditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, Reason: "AsExpected", Message: "old message"},
+			},
+			expectedEvents: []event{
+				{eventType: corev1.EventTypeNormal, reason: "AsExpected", message: "condition Degraded is False: new message"},
+			},
+		},
+		{
+			name:    "message only suppressed",
+			options: Options{SuppressMessageOnlyChanges: true},
+			testCondition: conditionsv1.Condition{
+				Type:    conditionsv1.ConditionDegraded,
+				Status:  corev1.ConditionFalse,
+				Reason:  "AsExpected",
+				Message: "new message",
+			},
+			startConditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, Reason: "AsExpected", Message: "old message"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeRecorder{}
+			recorder := NewRecorder(fake, tc.options)
+			conditions := append([]conditionsv1.Condition{}, tc.startConditions...)
+			conditionsv1.SetStatusConditionAndNotify(&corev1.ConfigMap{}, &conditions, tc.testCondition, recorder)
+
+			if len(fake.events) != len(tc.expectedEvents) {
+				t.Fatalf("Unexpected events '%v', expected '%v'", fake.events, tc.expectedEvents)
+			}
+			for i := range tc.expectedEvents {
+				if fake.events[i] != tc.expectedEvents[i] {
+					t.Errorf("Unexpected event '%v', expected '%v'", fake.events[i], tc.expectedEvents[i])
+				}
+			}
+		})
+	}
+}
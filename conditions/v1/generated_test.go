@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestProtobufRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		json string
+	}{
+		{
+			name: "all fields",
+			json: `{"type":"Available","status":"True","reason":"AsExpected","message":"All components are available","lastHeartbeatTime":"2021-01-01T12:00:00Z","lastTransitionTime":"2021-01-01T11:00:00Z"}`,
+		},
+		{
+			name: "unset timestamps",
+			json: `{"type":"Degraded","status":"Unknown","lastHeartbeatTime":null,"lastTransitionTime":null}`,
+		},
+		{
+			name: "prefixed type",
+			json: `{"type":"example.com/Ready","status":"False","reason":"Deploying","lastHeartbeatTime":"2021-01-01T12:00:00Z","lastTransitionTime":"2021-01-01T12:00:00Z"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fromJSON := Condition{}
+			if err := json.Unmarshal([]byte(tc.json), &fromJSON); err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+
+			data, err := fromJSON.Marshal()
+			if err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+			if len(data) != fromJSON.Size() {
+				t.Errorf("Unexpected protobuf size %d, expected %d", len(data), fromJSON.Size())
+			}
+			fromProtobuf := Condition{}
+			if err := fromProtobuf.Unmarshal(data); err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(fromProtobuf, fromJSON) {
+				t.Errorf("Unexpected condition '%v', expected '%v'", fromProtobuf, fromJSON)
+			}
+
+			roundTripped, err := json.Marshal(fromProtobuf)
+			if err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+			if string(roundTripped) != tc.json {
+				t.Errorf("Unexpected JSON '%s', expected '%s'", roundTripped, tc.json)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Package kstatus computes the kstatus result of custom resources using
+// conditions/v1, as tools like kpt and Flux do with
+// sigs.k8s.io/cli-utils/pkg/kstatus, and keeps the kstatus Reconciling and
+// Stalled conditions in sync with Progressing and Degraded.
+package kstatus
+
+import (
+	"fmt"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Status is the kstatus status of a resource.
+type Status string
+
+const (
+	// InProgressStatus indicates that the resource is being reconciled.
+	InProgressStatus Status = "InProgress"
+
+	// FailedStatus indicates that the reconciliation of the resource failed.
+	FailedStatus Status = "Failed"
+
+	// CurrentStatus indicates that the resource is reconciled and available.
+	CurrentStatus Status = "Current"
+
+	// TerminatingStatus indicates that the resource is being deleted.
+	TerminatingStatus Status = "Terminating"
+
+	// UnknownStatus indicates that the status of the resource cannot be
+	// determined from its conditions.
+	UnknownStatus Status = "Unknown"
+)
+
+const (
+	// ConditionReconciling is the kstatus condition type mirroring
+	// conditionsv1.ConditionProgressing.
+	ConditionReconciling conditionsv1.ConditionType = "Reconciling"
+
+	// ConditionStalled is the kstatus condition type mirroring
+	// conditionsv1.ConditionDegraded.
+	ConditionStalled conditionsv1.ConditionType = "Stalled"
+)
+
+// mirrors maps the condition types to the kstatus condition types kept in sync
+// with them.
+var mirrors = map[conditionsv1.ConditionType]conditionsv1.ConditionType{
+	conditionsv1.ConditionProgressing: ConditionReconciling,
+	conditionsv1.ConditionDegraded:    ConditionStalled,
+}
+
+// Result is the kstatus result of a resource.
+type Result struct {
+	Status  Status
+	Message string
+}
+
+// Compute returns the kstatus result of object, given the observedGeneration
+// and the conditions of its status. Resources not tracking an observed
+// generation should pass object.GetGeneration().
+//
+// As in kstatus, a resource is Terminating when it is being deleted, and
+// InProgress when its latest generation was not observed yet or when
+// Reconciling or Progressing is True. It is then Failed when Stalled or
+// Degraded is True, and Current when Available is True or not set, as kstatus
+// does not read Available. It is InProgress when Available is False, and
+// Unknown when Available is Unknown.
+func Compute(object metav1.Object, observedGeneration int64, conditions []conditionsv1.Condition) Result {
+	if object.GetDeletionTimestamp() != nil {
+		return Result{Status: TerminatingStatus, Message: "Resource scheduled for deletion"}
+	}
+	if observedGeneration < object.GetGeneration() {
+		return Result{
+			Status:  InProgressStatus,
+			Message: fmt.Sprintf("Resource generation %d was not observed yet, observed generation is %d", object.GetGeneration(), observedGeneration),
+		}
+	}
+
+	for _, conditionType := range []conditionsv1.ConditionType{ConditionReconciling, conditionsv1.ConditionProgressing} {
+		if condition := findTrue(conditions, conditionType); condition != nil {
+			return Result{Status: InProgressStatus, Message: message(condition)}
+		}
+	}
+	for _, conditionType := range []conditionsv1.ConditionType{ConditionStalled, conditionsv1.ConditionDegraded} {
+		if condition := findTrue(conditions, conditionType); condition != nil {
+			return Result{Status: FailedStatus, Message: message(condition)}
+		}
+	}
+
+	available := conditionsv1.FindStatusCondition(conditions, conditionsv1.ConditionAvailable)
+	switch {
+	case available == nil, available.Status == corev1.ConditionTrue:
+		return Result{Status: CurrentStatus, Message: "Resource is current"}
+	case available.Status == corev1.ConditionFalse:
+		return Result{Status: InProgressStatus, Message: message(available)}
+	default:
+		return Result{Status: UnknownStatus, Message: message(available)}
+	}
+}
+
+// SetStatusCondition behaves like conditionsv1.SetStatusCondition, and also
+// sets Reconciling to the status, reason and message of Progressing, and
+// Stalled to those of Degraded.
+func SetStatusCondition(conditions *[]conditionsv1.Condition, newCondition conditionsv1.Condition) bool {
+	if conditions == nil {
+		conditions = &[]conditionsv1.Condition{}
+	}
+	changed := conditionsv1.SetStatusCondition(conditions, newCondition)
+	if mirror, ok := mirrors[newCondition.Type]; ok {
+		newCondition.Type = mirror
+		changed = conditionsv1.SetStatusCondition(conditions, newCondition) || changed
+	}
+	return changed
+}
+
+// SetStatusConditionNoHeartbeat is like SetStatusCondition, but based on
+// conditionsv1.SetStatusConditionNoHeartbeat.
+func SetStatusConditionNoHeartbeat(conditions *[]conditionsv1.Condition, newCondition conditionsv1.Condition) bool {
+	if conditions == nil {
+		conditions = &[]conditionsv1.Condition{}
+	}
+	changed := conditionsv1.SetStatusConditionNoHeartbeat(conditions, newCondition)
+	if mirror, ok := mirrors[newCondition.Type]; ok {
+		newCondition.Type = mirror
+		changed = conditionsv1.SetStatusConditionNoHeartbeat(conditions, newCondition) || changed
+	}
+	return changed
+}
+
+// Sync sets Reconciling and Stalled from the Progressing and Degraded
+// conditions already set, for example by conditionsv1.SetStatusCondition. It
+// removes them when their counterpart is absent. The return value indicates
+// if this resulted in any changes.
+func Sync(conditions *[]conditionsv1.Condition) bool {
+	if conditions == nil {
+		return false
+	}
+	changed := false
+	for _, conditionType := range []conditionsv1.ConditionType{conditionsv1.ConditionProgressing, conditionsv1.ConditionDegraded} {
+		mirror := mirrors[conditionType]
+		condition := conditionsv1.FindStatusCondition(*conditions, conditionType)
+		if condition == nil {
+			if conditionsv1.FindStatusCondition(*conditions, mirror) != nil {
+				conditionsv1.RemoveStatusCondition(conditions, mirror)
+				changed = true
+			}
+			continue
+		}
+		mirrored := *condition
+		mirrored.Type = mirror
+		if conditionsv1.SetStatusConditionNoHeartbeat(conditions, mirrored) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func findTrue(conditions []conditionsv1.Condition, conditionType conditionsv1.ConditionType) *conditionsv1.Condition {
+	condition := conditionsv1.FindStatusCondition(conditions, conditionType)
+	if condition == nil || condition.Status != corev1.ConditionTrue {
+		return nil
+	}
+	return condition
+}
+
+// message describes condition with its message, its reason, or its status.
+func message(condition *conditionsv1.Condition) string {
+	switch {
+	case condition.Message != "":
+		return condition.Message
+	case condition.Reason != "":
+		return fmt.Sprintf("%s: %s", condition.Type, condition.Reason)
+	default:
+		return fmt.Sprintf("%s is %s", condition.Type, condition.Status)
+	}
+}
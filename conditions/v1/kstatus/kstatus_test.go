@@ -0,0 +1,178 @@
+package kstatus
+
+import (
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCompute(t *testing.T) {
+	now := metav1.Now()
+	testCases := []struct {
+		name               string
+		object             metav1.ObjectMeta
+		observedGeneration int64
+		conditions         []conditionsv1.Condition
+		expectedResult     Result
+	}{
+		{
+			name:           "terminating",
+			object:         metav1.ObjectMeta{Generation: 2, DeletionTimestamp: &now},
+			conditions:     []conditionsv1.Condition{{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue}},
+			expectedResult: Result{Status: TerminatingStatus, Message: "Resource scheduled for deletion"},
+		},
+		{
+			name:               "generation not observed",
+			object:             metav1.ObjectMeta{Generation: 2},
+			observedGeneration: 1,
+			conditions:         []conditionsv1.Condition{{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue}},
+			expectedResult:     Result{Status: InProgressStatus, Message: "Resource generation 2 was not observed yet, observed generation is 1"},
+		},
+		{
+			name:               "reconciling",
+			object:             metav1.ObjectMeta{Generation: 1},
+			observedGeneration: 1,
+			conditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue},
+				{Type: ConditionReconciling, Status: corev1.ConditionTrue, Reason: "Upgrading", Message: "Upgrading to 1.1"},
+			},
+			expectedResult: Result{Status: InProgressStatus, Message: "Upgrading to 1.1"},
+		},
+		{
+			name:               "progressing",
+			object:             metav1.ObjectMeta{Generation: 1},
+			observedGeneration: 1,
+			conditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionProgressing, Status: corev1.ConditionTrue, Reason: "Upgrading"},
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionTrue},
+			},
+			expectedResult: Result{Status: InProgressStatus, Message: "Progressing: Upgrading"},
+		},
+		{
+			name:               "stalled",
+			object:             metav1.ObjectMeta{Generation: 1},
+			observedGeneration: 1,
+			conditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue},
+				{Type: ConditionStalled, Status: corev1.ConditionTrue, Message: "Image pull failed"},
+			},
+			expectedResult: Result{Status: FailedStatus, Message: "Image pull failed"},
+		},
+		{
+			name:               "degraded",
+			object:             metav1.ObjectMeta{Generation: 1},
+			observedGeneration: 1,
+			conditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue},
+				{Type: conditionsv1.ConditionProgressing, Status: corev1.ConditionFalse},
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionTrue},
+			},
+			expectedResult: Result{Status: FailedStatus, Message: "Degraded is True"},
+		},
+		{
+			name:               "current",
+			object:             metav1.ObjectMeta{Generation: 1},
+			observedGeneration: 1,
+			conditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue},
+				{Type: conditionsv1.ConditionProgressing, Status: corev1.ConditionFalse},
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse},
+			},
+			expectedResult: Result{Status: CurrentStatus, Message: "Resource is current"},
+		},
+		{
+			name:               "not available",
+			object:             metav1.ObjectMeta{Generation: 1},
+			observedGeneration: 1,
+			conditions:         []conditionsv1.Condition{{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionFalse, Message: "Waiting for pods"}},
+			expectedResult:     Result{Status: InProgressStatus, Message: "Waiting for pods"},
+		},
+		{
+			name:               "availability unknown",
+			object:             metav1.ObjectMeta{Generation: 1},
+			observedGeneration: 1,
+			conditions:         []conditionsv1.Condition{{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionUnknown}},
+			expectedResult:     Result{Status: UnknownStatus, Message: "Available is Unknown"},
+		},
+		{
+			name:               "no conditions",
+			object:             metav1.ObjectMeta{Generation: 1},
+			observedGeneration: 1,
+			expectedResult:     Result{Status: CurrentStatus, Message: "Resource is current"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Compute(&tc.object, tc.observedGeneration, tc.conditions)
+			if result != tc.expectedResult {
+				t.Errorf("Unexpected result '%v', expected '%v'", result, tc.expectedResult)
+			}
+		})
+	}
+}
+
+func TestSetStatusCondition(t *testing.T) {
+	for _, set := range []func(*[]conditionsv1.Condition, conditionsv1.Condition) bool{SetStatusCondition, SetStatusConditionNoHeartbeat} {
+		conditions := []conditionsv1.Condition{}
+
+		if !set(&conditions, conditionsv1.Condition{Type: conditionsv1.ConditionProgressing, Status: corev1.ConditionTrue, Reason: "Upgrading"}) {
+			t.Errorf("Unexpected changed 'false', expected 'true'")
+		}
+		if !set(&conditions, conditionsv1.Condition{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse}) {
+			t.Errorf("Unexpected changed 'false', expected 'true'")
+		}
+		if !set(&conditions, conditionsv1.Condition{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue}) {
+			t.Errorf("Unexpected changed 'false', expected 'true'")
+		}
+		if set(&conditions, conditionsv1.Condition{Type: conditionsv1.ConditionProgressing, Status: corev1.ConditionTrue, Reason: "Upgrading"}) {
+			t.Errorf("Unexpected changed 'true', expected 'false'")
+		}
+		if len(conditions) != 5 {
+			t.Errorf("Unexpected conditions '%v', expected 5 conditions", conditions)
+		}
+
+		reconciling := conditionsv1.FindStatusCondition(conditions, ConditionReconciling)
+		if reconciling == nil || reconciling.Status != corev1.ConditionTrue || reconciling.Reason != "Upgrading" {
+			t.Errorf("Unexpected Reconciling condition '%v', expected True with the Upgrading reason", reconciling)
+		}
+		stalled := conditionsv1.FindStatusCondition(conditions, ConditionStalled)
+		if stalled == nil || stalled.Status != corev1.ConditionFalse {
+			t.Errorf("Unexpected Stalled condition '%v', expected False", stalled)
+		}
+	}
+}
+
+func TestSync(t *testing.T) {
+	conditions := []conditionsv1.Condition{
+		{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionTrue, Reason: "Timeout", Message: "Timed out"},
+		{Type: ConditionReconciling, Status: corev1.ConditionTrue},
+	}
+
+	if !Sync(&conditions) {
+		t.Errorf("Unexpected changed 'false', expected 'true'")
+	}
+	if conditionsv1.FindStatusCondition(conditions, ConditionReconciling) != nil {
+		t.Errorf("Unexpected Reconciling condition, expected it to be removed without Progressing")
+	}
+	stalled := conditionsv1.FindStatusCondition(conditions, ConditionStalled)
+	if stalled == nil || stalled.Status != corev1.ConditionTrue || stalled.Reason != "Timeout" || stalled.Message != "Timed out" {
+		t.Errorf("Unexpected Stalled condition '%v', expected it to mirror Degraded", stalled)
+	}
+	if Sync(&conditions) {
+		t.Errorf("Unexpected changed 'true', expected 'false'")
+	}
+	if Sync(nil) {
+		t.Errorf("Unexpected changed 'true', expected 'false'")
+	}
+}
+
+func TestPolarity(t *testing.T) {
+	for _, conditionType := range []conditionsv1.ConditionType{ConditionReconciling, ConditionStalled} {
+		if polarity := conditionsv1.PolarityOf(conditionType); polarity != conditionsv1.PolarityNegative {
+			t.Errorf("Unexpected polarity '%v' of %s, expected '%v'", polarity, conditionType, conditionsv1.PolarityNegative)
+		}
+	}
+}
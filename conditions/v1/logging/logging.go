@@ -0,0 +1,80 @@
+// Package logging logs the condition transitions reported by the notifying
+// setters of conditions/v1 through a logr.Logger.
+package logging
+
+import (
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultHeartbeatVerbosity is the verbosity heartbeat-only updates are logged
+// at when Options.HeartbeatVerbosity is nil.
+const DefaultHeartbeatVerbosity = 4
+
+// Options configures a Logger.
+type Options struct {
+	// HeartbeatVerbosity is the verbosity heartbeat-only updates are logged at.
+	// Defaults to DefaultHeartbeatVerbosity when nil.
+	HeartbeatVerbosity *int
+}
+
+// Logger is a conditionsv1.TransitionHandler logging every transition with the
+// conditionType, oldStatus, newStatus, reason, message and object keys.
+// oldStatus is empty when the condition was added.
+type Logger struct {
+	logger             logr.Logger
+	heartbeatVerbosity int
+}
+
+var _ conditionsv1.TransitionHandler = &Logger{}
+
+// NewLogger returns a Logger writing to logger.
+func NewLogger(logger logr.Logger, options Options) *Logger {
+	heartbeatVerbosity := DefaultHeartbeatVerbosity
+	if options.HeartbeatVerbosity != nil {
+		heartbeatVerbosity = *options.HeartbeatVerbosity
+	}
+	return &Logger{
+		logger:             logger,
+		heartbeatVerbosity: heartbeatVerbosity,
+	}
+}
+
+// OnTransition logs transition. Heartbeat-only updates are logged at the
+// heartbeat verbosity.
+func (l *Logger) OnTransition(object runtime.Object, transition conditionsv1.Transition) {
+	condition := transition.Current
+	oldStatus := ""
+	if transition.Previous != nil {
+		oldStatus = string(transition.Previous.Status)
+	}
+	keysAndValues := []interface{}{
+		"conditionType", string(condition.Type),
+		"oldStatus", oldStatus,
+		"newStatus", string(condition.Status),
+		"reason", condition.Reason,
+		"message", condition.Message,
+		"object", objectName(object),
+	}
+
+	if !transition.Changed() {
+		l.logger.V(l.heartbeatVerbosity).Info("condition heartbeat", keysAndValues...)
+		return
+	}
+	l.logger.Info("condition transition", keysAndValues...)
+}
+
+// objectName returns the "namespace/name" of object, or only its name for
+// cluster-scoped objects.
+func objectName(object runtime.Object) string {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return ""
+	}
+	if accessor.GetNamespace() == "" {
+		return accessor.GetName()
+	}
+	return accessor.GetNamespace() + "/" + accessor.GetName()
+}
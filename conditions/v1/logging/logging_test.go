@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLogger(t *testing.T) {
+	zero := 0
+	testCases := []struct {
+		name               string
+		verbosity          int
+		heartbeatVerbosity *int
+		testCondition      conditionsv1.Condition
+		startConditions    []conditionsv1.Condition
+		expectedLines      []string
+	}{
+		{
+			name: "added",
+			testCondition: conditionsv1.Condition{
+				Type:    conditionsv1.ConditionAvailable,
+				Status:  corev1.ConditionTrue,
+				Reason:  "AsExpected",
+				Message: "All is well",
+			},
+			expectedLines: []string{
+				`"level"=0 "msg"="condition transition" "conditionType"="Available" "oldStatus"="" "newStatus"="True" "reason"="AsExpected" "message"="All is well" "object"="test-namespace/foo"`,
+			},
+		},
+		{
+			name: "status change",
+			testCondition: conditionsv1.Condition{
+				Type:   conditionsv1.ConditionDegraded,
+				Status: corev1.ConditionTrue,
+				Reason: "Failing",
+			},
+			startConditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, Reason: "AsExpected"},
+			},
+			expectedLines: []string{
+				`"level"=0 "msg"="condition transition" "conditionType"="Degraded" "oldStatus"="False" "newStatus"="True" "reason"="Failing" "message"="" "object"="test-namespace/foo"`,
+			},
+		},
+		{
+			name: "heartbeat hidden",
+			testCondition: conditionsv1.Condition{
+				Type:   conditionsv1.ConditionDegraded,
+				Status: corev1.ConditionFalse,
+				Reason: "AsExpected",
+			},
+			startConditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, Reason: "AsExpected"},
+			},
+		},
+		{
+			name:               "heartbeat at verbosity 0",
+			heartbeatVerbosity: &zero,
+			testCondition: conditionsv1.Condition{
+				Type:   conditionsv1.ConditionDegraded,
+				Status: corev1.ConditionFalse,
+				Reason: "AsExpected",
+			},
+			startConditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, Reason: "AsExpected"},
+			},
+			expectedLines: []string{
+				`"level"=0 "msg"="condition heartbeat" "conditionType"="Degraded" "oldStatus"="False" "newStatus"="False" "reason"="AsExpected" "message"="" "object"="test-namespace/foo"`,
+			},
+		},
+		{
+			name:      "heartbeat verbose",
+			verbosity: DefaultHeartbeatVerbosity,
+			testCondition: conditionsv1.Condition{
+				Type:   conditionsv1.ConditionDegraded,
+				Status: corev1.ConditionFalse,
+				Reason: "AsExpected",
+			},
+			startConditions: []conditionsv1.Condition{
+				{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, Reason: "AsExpected"},
+			},
+			expectedLines: []string{
+				`"level"=4 "msg"="condition heartbeat" "conditionType"="Degraded" "oldStatus"="False" "newStatus"="False" "reason"="AsExpected" "message"="" "object"="test-namespace/foo"`,
+			},
+		},
+	}
+
+	object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "foo"}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lines []string
+			logger := NewLogger(funcr.New(func(prefix, args string) {
+				lines = append(lines, args)
+			}, funcr.Options{Verbosity: tc.verbosity}), Options{HeartbeatVerbosity: tc.heartbeatVerbosity})
+
+			conditions := append([]conditionsv1.Condition{}, tc.startConditions...)
+			conditionsv1.SetStatusConditionAndNotify(object, &conditions, tc.testCondition, logger)
+
+			if len(lines) != len(tc.expectedLines) {
+				t.Fatalf("Unexpected log lines '%v', expected '%v'", lines, tc.expectedLines)
+			}
+			for i := range tc.expectedLines {
+				if lines[i] != tc.expectedLines[i] {
+					t.Errorf("Unexpected log line '%v', expected '%v'", lines[i], tc.expectedLines[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package metrics records the state and the transitions of conditions
+// managed through conditions/v1 into a Collector.
+package metrics
+
+import (
+	"sync"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	objectreferencesv1 "github.com/openshift/custom-resource-status/objectreferences/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ObjectKey identifies the object owning the conditions.
+type ObjectKey struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// Collector receives the metrics computed by a Recorder. The
+// conditions/v1/metrics/prometheus package provides an implementation backed by
+// a Prometheus registry.
+type Collector interface {
+	// SetConditionStatus sets the gauge of conditionType and status for object.
+	SetConditionStatus(object ObjectKey, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, value float64)
+
+	// DeleteConditionStatus deletes the gauge of conditionType and status for object.
+	DeleteConditionStatus(object ObjectKey, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus)
+
+	// IncTransitions increments the counter of transitions of conditionType
+	// from status from to status to with reason. from is empty when the
+	// condition was added.
+	IncTransitions(conditionType conditionsv1.ConditionType, from, to corev1.ConditionStatus, reason string)
+}
+
+// statuses are the values a gauge is maintained for, per condition type.
+var statuses = []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown}
+
+// Recorder is a conditionsv1.TransitionHandler maintaining, for every
+// condition of every object it was notified about, a gauge per status that
+// is 1 for the current status and 0 for the others, and counting status
+// transitions.
+type Recorder struct {
+	collector Collector
+
+	lock sync.Mutex
+	// types records the condition types with gauges, per object, so they can
+	// be deleted by Forget.
+	types map[ObjectKey]map[conditionsv1.ConditionType]bool
+}
+
+var _ conditionsv1.TransitionHandler = &Recorder{}
+
+// NewRecorder returns a Recorder writing to collector.
+func NewRecorder(collector Collector) *Recorder {
+	return &Recorder{
+		collector: collector,
+		types:     map[ObjectKey]map[conditionsv1.ConditionType]bool{},
+	}
+}
+
+// OnTransition updates the gauges of the condition and counts the transition
+// when its status changed.
+func (r *Recorder) OnTransition(object runtime.Object, transition conditionsv1.Transition) {
+	key := KeyForObject(object)
+	condition := transition.Current
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.setLocked(key, condition)
+	if transition.StatusChanged() {
+		from := corev1.ConditionStatus("")
+		if transition.Previous != nil {
+			from = transition.Previous.Status
+		}
+		r.collector.IncTransitions(condition.Type, from, condition.Status, condition.Reason)
+	}
+}
+
+// Observe sets the gauges of object to the state of conditions, deleting the
+// gauges of condition types that are not present anymore. It is useful to
+// populate the gauges for objects whose conditions were not updated since the
+// process started.
+func (r *Recorder) Observe(object runtime.Object, conditions []conditionsv1.Condition) {
+	key := KeyForObject(object)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for conditionType := range r.types[key] {
+		if conditionsv1.FindStatusCondition(conditions, conditionType) == nil {
+			r.deleteLocked(key, conditionType)
+		}
+	}
+	for _, condition := range conditions {
+		r.setLocked(key, condition)
+	}
+}
+
+// Forget deletes all the gauges of object. It must be called when the object
+// is deleted.
+func (r *Recorder) Forget(object runtime.Object) {
+	key := KeyForObject(object)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for conditionType := range r.types[key] {
+		r.deleteLocked(key, conditionType)
+	}
+	delete(r.types, key)
+}
+
+func (r *Recorder) setLocked(key ObjectKey, condition conditionsv1.Condition) {
+	if r.types[key] == nil {
+		r.types[key] = map[conditionsv1.ConditionType]bool{}
+	}
+	r.types[key][condition.Type] = true
+	for _, status := range statuses {
+		value := 0.0
+		if condition.Status == status {
+			value = 1.0
+		}
+		r.collector.SetConditionStatus(key, condition.Type, status, value)
+	}
+}
+
+func (r *Recorder) deleteLocked(key ObjectKey, conditionType conditionsv1.ConditionType) {
+	for _, status := range statuses {
+		r.collector.DeleteConditionStatus(key, conditionType, status)
+	}
+	delete(r.types[key], conditionType)
+}
+
+// KeyForObject returns the ObjectKey of object, with the kind, namespace and
+// name of objectreferencesv1.ObjectReferenceFor.
+func KeyForObject(object runtime.Object) ObjectKey {
+	ref := objectreferencesv1.ObjectReferenceFor(object)
+	return ObjectKey{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name}
+}
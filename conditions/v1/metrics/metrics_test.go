@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type gaugeKey struct {
+	object        ObjectKey
+	conditionType conditionsv1.ConditionType
+	status        corev1.ConditionStatus
+}
+
+type transitionKey struct {
+	conditionType conditionsv1.ConditionType
+	from, to      corev1.ConditionStatus
+	reason        string
+}
+
+type fakeCollector struct {
+	gauges      map[gaugeKey]float64
+	transitions map[transitionKey]int
+}
+
+func newFakeCollector() *fakeCollector {
+	return &fakeCollector{
+		gauges:      map[gaugeKey]float64{},
+		transitions: map[transitionKey]int{},
+	}
+}
+
+func (f *fakeCollector) SetConditionStatus(object ObjectKey, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, value float64) {
+	f.gauges[gaugeKey{object, conditionType, status}] = value
+}
+
+func (f *fakeCollector) DeleteConditionStatus(object ObjectKey, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus) {
+	delete(f.gauges, gaugeKey{object, conditionType, status})
+}
+
+func (f *fakeCollector) IncTransitions(conditionType conditionsv1.ConditionType, from, to corev1.ConditionStatus, reason string) {
+	f.transitions[transitionKey{conditionType, from, to, reason}]++
+}
+
+func TestRecorder(t *testing.T) {
+	collector := newFakeCollector()
+	recorder := NewRecorder(collector)
+	object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "foo"}}
+	key := ObjectKey{Kind: "ConfigMap", Namespace: "test-namespace", Name: "foo"}
+
+	conditions := []conditionsv1.Condition{}
+	set := func(status corev1.ConditionStatus, reason string) {
+		conditionsv1.SetStatusConditionAndNotify(object, &conditions, conditionsv1.Condition{
+			Type:   conditionsv1.ConditionDegraded,
+			Status: status,
+			Reason: reason,
+		}, recorder)
+	}
+	set(corev1.ConditionFalse, "AsExpected")
+	set(corev1.ConditionFalse, "AsExpected")
+	set(corev1.ConditionTrue, "Failing")
+
+	expectedGauges := map[gaugeKey]float64{
+		{key, conditionsv1.ConditionDegraded, corev1.ConditionTrue}:    1,
+		{key, conditionsv1.ConditionDegraded, corev1.ConditionFalse}:   0,
+		{key, conditionsv1.ConditionDegraded, corev1.ConditionUnknown}: 0,
+	}
+	compareGauges(t, collector.gauges, expectedGauges)
+
+	expectedTransitions := map[transitionKey]int{
+		{conditionsv1.ConditionDegraded, "", corev1.ConditionFalse, "AsExpected"}:                1,
+		{conditionsv1.ConditionDegraded, corev1.ConditionFalse, corev1.ConditionTrue, "Failing"}: 1,
+	}
+	if len(collector.transitions) != len(expectedTransitions) {
+		t.Errorf("Unexpected transitions '%v', expected '%v'", collector.transitions, expectedTransitions)
+	}
+	for k, v := range expectedTransitions {
+		if collector.transitions[k] != v {
+			t.Errorf("Unexpected count for transition '%v': expected: %d; actual: %d", k, v, collector.transitions[k])
+		}
+	}
+
+	recorder.Forget(object)
+	compareGauges(t, collector.gauges, map[gaugeKey]float64{})
+}
+
+func TestRecorderObserve(t *testing.T) {
+	collector := newFakeCollector()
+	recorder := NewRecorder(collector)
+	object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	key := ObjectKey{Kind: "ConfigMap", Name: "foo"}
+
+	recorder.Observe(object, []conditionsv1.Condition{
+		{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue},
+		{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionUnknown},
+	})
+	recorder.Observe(object, []conditionsv1.Condition{
+		{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionFalse},
+	})
+
+	expectedGauges := map[gaugeKey]float64{
+		{key, conditionsv1.ConditionAvailable, corev1.ConditionTrue}:    0,
+		{key, conditionsv1.ConditionAvailable, corev1.ConditionFalse}:   1,
+		{key, conditionsv1.ConditionAvailable, corev1.ConditionUnknown}: 0,
+	}
+	compareGauges(t, collector.gauges, expectedGauges)
+	if len(collector.transitions) != 0 {
+		t.Errorf("Unexpected transitions '%v', expected none", collector.transitions)
+	}
+}
+
+func TestKeyForObject(t *testing.T) {
+	object := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ExampleApp"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "foo"},
+	}
+	expectedKey := ObjectKey{Kind: "ExampleApp", Namespace: "test-namespace", Name: "foo"}
+	if key := KeyForObject(object); key != expectedKey {
+		t.Errorf("Unexpected key '%v', expected '%v'", key, expectedKey)
+	}
+}
+
+func compareGauges(t *testing.T, gotGauges, expectedGauges map[gaugeKey]float64) {
+	if len(gotGauges) != len(expectedGauges) {
+		t.Errorf("Unexpected gauges '%v', expected '%v'", gotGauges, expectedGauges)
+	}
+	for k, v := range expectedGauges {
+		got, ok := gotGauges[k]
+		if !ok || got != v {
+			t.Errorf("Unexpected value for gauge '%v': expected: %v; actual: %v", k, v, got)
+		}
+	}
+}
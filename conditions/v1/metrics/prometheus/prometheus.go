@@ -0,0 +1,64 @@
+// Package prometheus provides a metrics.Collector backed by a Prometheus registry.
+package prometheus
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/openshift/custom-resource-status/conditions/v1/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ConditionMetricName is the name of the gauge holding the status of conditions.
+	ConditionMetricName = "custom_resource_condition"
+
+	// TransitionsMetricName is the name of the counter of condition transitions.
+	TransitionsMetricName = "custom_resource_condition_transitions_total"
+)
+
+// Collector is a metrics.Collector exposing the metrics as a gauge labeled with
+// kind, namespace, name, type and status, and a counter labeled with type,
+// from, to and reason.
+type Collector struct {
+	conditions  *prometheus.GaugeVec
+	transitions *prometheus.CounterVec
+}
+
+var _ metrics.Collector = &Collector{}
+
+// NewCollector returns a Collector whose metrics are registered in registerer.
+func NewCollector(registerer prometheus.Registerer) (*Collector, error) {
+	c := &Collector{
+		conditions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: ConditionMetricName,
+			Help: "The status of the conditions of custom resources, 1 for the current status and 0 for the others.",
+		}, []string{"kind", "namespace", "name", "type", "status"}),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: TransitionsMetricName,
+			Help: "The number of status transitions of the conditions of custom resources.",
+		}, []string{"type", "from", "to", "reason"}),
+	}
+	if err := registerer.Register(c.conditions); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(c.transitions); err != nil {
+		registerer.Unregister(c.conditions)
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetConditionStatus sets the gauge of conditionType and status for object.
+func (c *Collector) SetConditionStatus(object metrics.ObjectKey, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, value float64) {
+	c.conditions.WithLabelValues(object.Kind, object.Namespace, object.Name, string(conditionType), string(status)).Set(value)
+}
+
+// DeleteConditionStatus deletes the gauge of conditionType and status for object.
+func (c *Collector) DeleteConditionStatus(object metrics.ObjectKey, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus) {
+	c.conditions.DeleteLabelValues(object.Kind, object.Namespace, object.Name, string(conditionType), string(status))
+}
+
+// IncTransitions increments the counter of transitions.
+func (c *Collector) IncTransitions(conditionType conditionsv1.ConditionType, from, to corev1.ConditionStatus, reason string) {
+	c.transitions.WithLabelValues(string(conditionType), string(from), string(to), reason).Inc()
+}
@@ -0,0 +1,59 @@
+package prometheus
+
+import (
+	"strings"
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/openshift/custom-resource-status/conditions/v1/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	collector, err := NewCollector(registry)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	recorder := metrics.NewRecorder(collector)
+
+	object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "foo"}}
+	conditions := []conditionsv1.Condition{}
+	conditionsv1.SetStatusConditionAndNotify(object, &conditions, conditionsv1.Condition{
+		Type:   conditionsv1.ConditionAvailable,
+		Status: corev1.ConditionTrue,
+		Reason: "AsExpected",
+	}, recorder)
+
+	expected := `
+# HELP custom_resource_condition The status of the conditions of custom resources, 1 for the current status and 0 for the others.
+# TYPE custom_resource_condition gauge
+custom_resource_condition{kind="ConfigMap",name="foo",namespace="test-namespace",status="False",type="Available"} 0
+custom_resource_condition{kind="ConfigMap",name="foo",namespace="test-namespace",status="True",type="Available"} 1
+custom_resource_condition{kind="ConfigMap",name="foo",namespace="test-namespace",status="Unknown",type="Available"} 0
+# HELP custom_resource_condition_transitions_total The number of status transitions of the conditions of custom resources.
+# TYPE custom_resource_condition_transitions_total counter
+custom_resource_condition_transitions_total{from="",reason="AsExpected",to="True",type="Available"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+
+	recorder.Forget(object)
+	if count := testutil.CollectAndCount(collector.conditions); count != 0 {
+		t.Errorf("Unexpected number of gauges after Forget: expected: 0; actual: %d", count)
+	}
+}
+
+func TestNewCollectorAlreadyRegistered(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	if _, err := NewCollector(registry); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	if _, err := NewCollector(registry); err == nil {
+		t.Error("Expected an error registering the metrics twice")
+	}
+}
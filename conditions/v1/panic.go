@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReasonReconcilePanic is the reason of the Degraded condition set by
+// RecoverReconcile when the reconcile function panics.
+const ReasonReconcilePanic = "ReconcilePanic"
+
+const (
+	// maxPanicFrames is the number of stack frames summarized in the message.
+	maxPanicFrames = 5
+
+	// maxPanicValueLength is the maximum length of the panic value in the
+	// message, keeping it well under the maximum length of a message.
+	maxPanicValueLength = 1024
+)
+
+// recoverReconcileName is the function name of RecoverReconcile in stack frames.
+var recoverReconcileName = reflect.TypeOf(Condition{}).PkgPath() + ".RecoverReconcile"
+
+// PanicError is the error returned by RecoverReconcile when the reconcile
+// function panics.
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+// +protobuf=false
+type PanicError struct {
+	// Value is the value the reconcile function panicked with.
+	Value interface{}
+
+	// Stack summarizes the innermost frames of the panicking goroutine, from
+	// the panic up to the reconcile function.
+	Stack []string
+}
+
+// Error returns the panic value and the stack summary.
+func (e *PanicError) Error() string {
+	value := []rune(fmt.Sprint(e.Value))
+	if len(value) > maxPanicValueLength {
+		value = append(value[:maxPanicValueLength-3], []rune("...")...)
+	}
+	message := fmt.Sprintf("reconcile panicked: %s", string(value))
+	if len(e.Stack) > 0 {
+		message = fmt.Sprintf("%s at %s", message, strings.Join(e.Stack, " < "))
+	}
+	return message
+}
+
+// Unwrap returns the panic value when it is an error, for errors.Is and
+// errors.As.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// RecoverReconcile calls reconcile with conditions and returns its error. When
+// reconcile panics, it recovers, sets ConditionDegraded to `True` with
+// ReasonReconcilePanic and the panic value and a summary of the stack as
+// message, and returns a *PanicError. A panic with a nil value is recovered
+// too, its Value being nil.
+func RecoverReconcile(conditions *[]Condition, reconcile func(conditions *[]Condition) error) (err error) {
+	completed := false
+	defer func() {
+		// recover returns nil for panic(nil), so rely on completed instead.
+		value := recover()
+		if completed {
+			return
+		}
+		panicErr := &PanicError{Value: value, Stack: panicStack()}
+		SetStatusCondition(conditions, Condition{
+			Type:    ConditionDegraded,
+			Status:  corev1.ConditionTrue,
+			Reason:  ReasonReconcilePanic,
+			Message: panicErr.Error(),
+		})
+		err = panicErr
+	}()
+
+	err = reconcile(conditions)
+	completed = true
+	return err
+}
+
+// panicStack returns up to maxPanicFrames frames of the panicking goroutine,
+// innermost first, starting after the runtime panic frames and stopping at
+// RecoverReconcile. It must be called from the deferred function recovering.
+func panicStack() []string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+
+	stack := []string{}
+	panicking := false
+	for {
+		frame, more := frames.Next()
+		switch {
+		case frame.Function == recoverReconcileName:
+			return stack
+		case frame.Function == "runtime.gopanic":
+			panicking = true
+		case panicking && !strings.HasPrefix(frame.Function, "runtime.") && len(stack) < maxPanicFrames:
+			stack = append(stack, fmt.Sprintf("%s (%s:%d)", shortFunction(frame.Function), filepath.Base(frame.File), frame.Line))
+		}
+		if !more {
+			return stack
+		}
+	}
+}
+
+// shortFunction strips the import path from function, keeping the package name.
+func shortFunction(function string) string {
+	return function[strings.LastIndex(function, "/")+1:]
+}
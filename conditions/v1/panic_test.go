@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func panicking(conditions *[]Condition) error {
+	var conditionsByType map[ConditionType]Condition
+	conditionsByType[ConditionAvailable] = Condition{}
+	return nil
+}
+
+func TestRecoverReconcile(t *testing.T) {
+	testCases := []struct {
+		name             string
+		reconcile        func(conditions *[]Condition) error
+		expectedErr      error
+		expectedPanic    bool
+		expectedContains []string
+	}{
+		{
+			name:      "success",
+			reconcile: func(conditions *[]Condition) error { return nil },
+		},
+		{
+			name:        "error",
+			reconcile:   func(conditions *[]Condition) error { return errTimeout },
+			expectedErr: errTimeout,
+		},
+		{
+			name:             "panic with a value",
+			reconcile:        func(conditions *[]Condition) error { panic("unexpected state") },
+			expectedPanic:    true,
+			expectedContains: []string{"reconcile panicked: unexpected state at v1.TestRecoverReconcile.func", "panic_test.go:"},
+		},
+		{
+			name:             "panic with an error",
+			reconcile:        func(conditions *[]Condition) error { panic(errTimeout) },
+			expectedErr:      errTimeout,
+			expectedPanic:    true,
+			expectedContains: []string{"reconcile panicked: timed out waiting for pods at "},
+		},
+		{
+			name:             "panic with nil",
+			reconcile:        func(conditions *[]Condition) error { panic(nil) },
+			expectedPanic:    true,
+			expectedContains: []string{"reconcile panicked: <nil> at v1.TestRecoverReconcile.func"},
+		},
+		{
+			name:             "runtime panic",
+			reconcile:        panicking,
+			expectedPanic:    true,
+			expectedContains: []string{"reconcile panicked: assignment to entry in nil map at v1.panicking (panic_test.go:"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := []Condition{}
+			err := RecoverReconcile(&conditions, tc.reconcile)
+
+			if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Unexpected error '%v', expected '%v'", err, tc.expectedErr)
+			}
+			var panicErr *PanicError
+			if errors.As(err, &panicErr) != tc.expectedPanic {
+				t.Fatalf("Unexpected error '%v', expected a panic error '%v'", err, tc.expectedPanic)
+			}
+			if !tc.expectedPanic {
+				if tc.expectedErr == nil && err != nil {
+					t.Errorf("Error occurred unexpectedly: %v", err)
+				}
+				if len(conditions) != 0 {
+					t.Errorf("Unexpected conditions '%v', expected none", conditions)
+				}
+				return
+			}
+
+			condition := FindStatusCondition(conditions, ConditionDegraded)
+			if condition == nil || condition.Status != corev1.ConditionTrue || condition.Reason != ReasonReconcilePanic {
+				t.Fatalf("Unexpected condition '%v', expected Degraded True with the ReconcilePanic reason", condition)
+			}
+			if condition.Message != err.Error() {
+				t.Errorf("Unexpected message '%v', expected '%v'", condition.Message, err.Error())
+			}
+			for _, expected := range tc.expectedContains {
+				if !strings.Contains(condition.Message, expected) {
+					t.Errorf("Unexpected message '%v', expected it to contain '%v'", condition.Message, expected)
+				}
+			}
+			if strings.Contains(condition.Message, "v1.RecoverReconcile") || strings.Contains(condition.Message, "runtime.") {
+				t.Errorf("Unexpected message '%v', expected only the frames of the reconcile function", condition.Message)
+			}
+			if len(panicErr.Stack) > maxPanicFrames {
+				t.Errorf("Unexpected stack '%v', expected at most %d frames", panicErr.Stack, maxPanicFrames)
+			}
+		})
+	}
+}
+
+func TestPanicErrorTruncation(t *testing.T) {
+	err := &PanicError{Value: strings.Repeat("x", 2*maxPanicValueLength)}
+	if length := len(err.Error()); length != len("reconcile panicked: ")+maxPanicValueLength {
+		t.Errorf("Unexpected length '%v', expected '%v'", length, len("reconcile panicked: ")+maxPanicValueLength)
+	}
+}
@@ -0,0 +1,75 @@
+// Package phase derives a single phase, for users and printer columns, from a
+// slice of conditions through ordered rules.
+package phase
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/openshift/custom-resource-status/conditions/v1/selector"
+)
+
+// Phase summarizes the state of a resource.
+type Phase string
+
+const (
+	// PhasePending indicates that the resource is not available yet and no
+	// progress is being made, for example before the first reconciliation.
+	PhasePending Phase = "Pending"
+
+	// PhaseProgressing indicates that the operator is making changes to the
+	// resources it maintains.
+	PhaseProgressing Phase = "Progressing"
+
+	// PhaseReady indicates that the resources maintained by the operator are
+	// available.
+	PhaseReady Phase = "Ready"
+
+	// PhaseDegraded indicates that the resources maintained by the operator are
+	// available, but not functioning completely.
+	PhaseDegraded Phase = "Degraded"
+
+	// PhaseFailed indicates that the resources maintained by the operator are
+	// degraded and not available.
+	PhaseFailed Phase = "Failed"
+)
+
+// Rule derives Phase when Selector matches the conditions.
+type Rule struct {
+	Phase    Phase
+	Selector selector.Selector
+}
+
+// Rules are evaluated in order, the first matching rule derives the phase.
+type Rules []Rule
+
+// DefaultRules derive the phase from the built-in condition types:
+//
+//	Failed        Degraded=True,Available!=True
+//	Degraded      Degraded=True
+//	Progressing   Progressing=True
+//	Ready         Available=True
+//	Pending       otherwise
+//
+// Upgradeable does not affect the phase.
+var DefaultRules = Rules{
+	{Phase: PhaseFailed, Selector: selector.MustParse("Degraded=True,Available!=True")},
+	{Phase: PhaseDegraded, Selector: selector.MustParse("Degraded=True")},
+	{Phase: PhaseProgressing, Selector: selector.MustParse("Progressing=True")},
+	{Phase: PhaseReady, Selector: selector.MustParse("Available=True")},
+	{Phase: PhasePending, Selector: selector.Everything()},
+}
+
+// Derive returns the phase of the first rule matching conditions, or an empty
+// phase when no rule matches.
+func (r Rules) Derive(conditions []conditionsv1.Condition) Phase {
+	for _, rule := range r {
+		if rule.Selector.Matches(conditions) {
+			return rule.Phase
+		}
+	}
+	return ""
+}
+
+// Derive returns the phase of conditions according to DefaultRules.
+func Derive(conditions []conditionsv1.Condition) Phase {
+	return DefaultRules.Derive(conditions)
+}
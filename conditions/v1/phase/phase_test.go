@@ -0,0 +1,91 @@
+package phase
+
+import (
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/openshift/custom-resource-status/conditions/v1/selector"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func conditions(statuses ...interface{}) []conditionsv1.Condition {
+	conditions := []conditionsv1.Condition{}
+	for i := 0; i < len(statuses); i += 2 {
+		conditions = append(conditions, conditionsv1.Condition{
+			Type:   statuses[i].(conditionsv1.ConditionType),
+			Status: statuses[i+1].(corev1.ConditionStatus),
+		})
+	}
+	return conditions
+}
+
+func TestDerive(t *testing.T) {
+	testCases := []struct {
+		name          string
+		conditions    []conditionsv1.Condition
+		expectedPhase Phase
+	}{
+		{
+			name:          "no conditions",
+			conditions:    nil,
+			expectedPhase: PhasePending,
+		},
+		{
+			name:          "not available",
+			conditions:    conditions(conditionsv1.ConditionAvailable, corev1.ConditionFalse, conditionsv1.ConditionProgressing, corev1.ConditionFalse),
+			expectedPhase: PhasePending,
+		},
+		{
+			name:          "progressing",
+			conditions:    conditions(conditionsv1.ConditionAvailable, corev1.ConditionFalse, conditionsv1.ConditionProgressing, corev1.ConditionTrue),
+			expectedPhase: PhaseProgressing,
+		},
+		{
+			name:          "upgrading",
+			conditions:    conditions(conditionsv1.ConditionAvailable, corev1.ConditionTrue, conditionsv1.ConditionProgressing, corev1.ConditionTrue),
+			expectedPhase: PhaseProgressing,
+		},
+		{
+			name:          "ready",
+			conditions:    conditions(conditionsv1.ConditionAvailable, corev1.ConditionTrue, conditionsv1.ConditionProgressing, corev1.ConditionFalse, conditionsv1.ConditionDegraded, corev1.ConditionFalse, conditionsv1.ConditionUpgradeable, corev1.ConditionFalse),
+			expectedPhase: PhaseReady,
+		},
+		{
+			name:          "degraded",
+			conditions:    conditions(conditionsv1.ConditionAvailable, corev1.ConditionTrue, conditionsv1.ConditionProgressing, corev1.ConditionTrue, conditionsv1.ConditionDegraded, corev1.ConditionTrue),
+			expectedPhase: PhaseDegraded,
+		},
+		{
+			name:          "failed",
+			conditions:    conditions(conditionsv1.ConditionAvailable, corev1.ConditionFalse, conditionsv1.ConditionDegraded, corev1.ConditionTrue),
+			expectedPhase: PhaseFailed,
+		},
+		{
+			name:          "degraded without availability",
+			conditions:    conditions(conditionsv1.ConditionDegraded, corev1.ConditionTrue),
+			expectedPhase: PhaseFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if phase := Derive(tc.conditions); phase != tc.expectedPhase {
+				t.Errorf("Unexpected phase '%v', expected '%v'", phase, tc.expectedPhase)
+			}
+		})
+	}
+}
+
+func TestCustomRules(t *testing.T) {
+	rules := Rules{
+		{Phase: "Blocked", Selector: selector.MustParse("Upgradeable=False")},
+		{Phase: PhaseReady, Selector: selector.MustParse("Available=True")},
+	}
+
+	if phase := rules.Derive(conditions(conditionsv1.ConditionAvailable, corev1.ConditionTrue, conditionsv1.ConditionUpgradeable, corev1.ConditionFalse)); phase != "Blocked" {
+		t.Errorf("Unexpected phase '%v', expected 'Blocked'", phase)
+	}
+	if phase := rules.Derive(conditions(conditionsv1.ConditionAvailable, corev1.ConditionFalse)); phase != "" {
+		t.Errorf("Unexpected phase '%v', expected no phase", phase)
+	}
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Polarity describes which status of a condition type is the healthy one.
+type Polarity string
+
+const (
+	// PolarityPositive is used for condition types where `True` is healthy and
+	// `False` is abnormal, like ConditionAvailable.
+	PolarityPositive Polarity = "Positive"
+
+	// PolarityNegative is used for condition types where `True` is abnormal and
+	// `False` is healthy, like ConditionDegraded.
+	PolarityNegative Polarity = "Negative"
+
+	// PolarityNeutral is used for condition types where no status is abnormal,
+	// like ConditionProgressing.
+	PolarityNeutral Polarity = "Neutral"
+)
+
+// PolarityOf returns the polarity of the provided conditionType.
+// ConditionDegraded and the kstatus Reconciling and Stalled types, mirrored
+// by the conditions/v1/kstatus package, are negative, ConditionProgressing is
+// neutral, and every other type, including user-defined ones, is positive.
+func PolarityOf(conditionType ConditionType) Polarity {
+	switch conditionType {
+	case ConditionDegraded, "Reconciling", "Stalled":
+		return PolarityNegative
+	case ConditionProgressing:
+		return PolarityNeutral
+	default:
+		return PolarityPositive
+	}
+}
+
+// IsAbnormal returns true when status is the unhealthy status for the polarity.
+// `Unknown` is never considered abnormal.
+func (p Polarity) IsAbnormal(status corev1.ConditionStatus) bool {
+	switch p {
+	case PolarityPositive:
+		return status == corev1.ConditionFalse
+	case PolarityNegative:
+		return status == corev1.ConditionTrue
+	default:
+		return false
+	}
+}
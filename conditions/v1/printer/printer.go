@@ -0,0 +1,172 @@
+// Package printer renders conditions for humans, as an aligned table or as
+// kubectl-describe-style blocks.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// unknownAge is displayed in place of the age of unset timestamps.
+const unknownAge = "<unknown>"
+
+// Options configures how conditions are printed.
+type Options struct {
+	// Now is the time ages are computed from. Defaults to time.Now().
+	Now time.Time
+
+	// MaxMessageWidth is the maximum number of characters of a message per
+	// line. Longer messages are truncated, or wrapped when WrapMessages is set.
+	// Zero means unlimited.
+	MaxMessageWidth int
+
+	// WrapMessages wraps messages longer than MaxMessageWidth on several lines
+	// instead of truncating them.
+	WrapMessages bool
+}
+
+func (o Options) now() time.Time {
+	if o.Now.IsZero() {
+		return time.Now()
+	}
+	return o.Now
+}
+
+// PrintTable writes conditions to w as an aligned table with the TYPE, STATUS,
+// REASON, AGE and MESSAGE columns. AGE is computed from LastTransitionTime.
+func PrintTable(w io.Writer, conditions []conditionsv1.Condition, options Options) error {
+	now := options.now()
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tSTATUS\tREASON\tAGE\tMESSAGE")
+	for _, condition := range conditions {
+		lines := messageLines(condition.Message, options)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, Age(condition.LastTransitionTime, now), lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(tw, "\t\t\t\t%s\n", line)
+		}
+	}
+	return flush(w, tw, buf)
+}
+
+// PrintDescribe writes conditions to w as kubectl-describe-style blocks, one
+// per condition, separated by an empty line.
+func PrintDescribe(w io.Writer, conditions []conditionsv1.Condition, options Options) error {
+	now := options.now()
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 8, 2, ' ', 0)
+	for i, condition := range conditions {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "Type:\t%s\n", condition.Type)
+		fmt.Fprintf(tw, "Status:\t%s\n", condition.Status)
+		fmt.Fprintf(tw, "Reason:\t%s\n", condition.Reason)
+		lines := messageLines(condition.Message, options)
+		fmt.Fprintf(tw, "Message:\t%s\n", lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(tw, "\t%s\n", line)
+		}
+		fmt.Fprintf(tw, "Last Transition Time:\t%s\n", timestamp(condition.LastTransitionTime, now))
+		fmt.Fprintf(tw, "Last Heartbeat Time:\t%s\n", timestamp(condition.LastHeartbeatTime, now))
+	}
+	return flush(w, tw, buf)
+}
+
+// flush flushes tw into buf and copies buf to w without the padding tw leaves
+// at the end of lines with empty trailing cells.
+func flush(w io.Writer, tw *tabwriter.Writer, buf *bytes.Buffer) error {
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		if _, err := io.WriteString(w, strings.TrimRight(line, " \n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Age returns the time elapsed between t and now in the format used by kubectl
+// (ie. "5m" or "3d4h"), or "<unknown>" when t is unset.
+func Age(t metav1.Time, now time.Time) string {
+	if t.IsZero() {
+		return unknownAge
+	}
+	return duration.HumanDuration(now.Sub(t.Time))
+}
+
+// timestamp returns t in RFC 3339 followed by its age.
+func timestamp(t metav1.Time, now time.Time) string {
+	if t.IsZero() {
+		return unknownAge
+	}
+	return fmt.Sprintf("%s (%s ago)", t.UTC().Format(time.RFC3339), Age(t, now))
+}
+
+// messageLines splits message into the lines to print. Newlines in message are
+// always honored. It always returns at least one line.
+func messageLines(message string, options Options) []string {
+	lines := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		switch {
+		case options.MaxMessageWidth <= 0:
+			lines = append(lines, line)
+		case options.WrapMessages:
+			lines = append(lines, wrap(line, options.MaxMessageWidth)...)
+		default:
+			lines = append(lines, Truncate(line, options.MaxMessageWidth))
+		}
+	}
+	return lines
+}
+
+// Truncate shortens s to at most width characters, replacing the end of s
+// with "..." when it is too long.
+func Truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// wrap splits s in lines of at most width characters, breaking on spaces when
+// possible.
+func wrap(s string, width int) []string {
+	lines := []string{}
+	line := []rune{}
+	for _, word := range strings.Fields(s) {
+		runes := []rune(word)
+		if len(line) > 0 && len(line)+1+len(runes) > width {
+			lines = append(lines, string(line))
+			line = []rune{}
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, runes...)
+		for len(line) > width {
+			lines = append(lines, string(line[:width]))
+			line = line[width:]
+		}
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
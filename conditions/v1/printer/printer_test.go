@@ -0,0 +1,140 @@
+package printer
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	now        = time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	conditions = []conditionsv1.Condition{
+		{
+			Type:               conditionsv1.ConditionAvailable,
+			Status:             corev1.ConditionTrue,
+			Reason:             "AsExpected",
+			Message:            "All components are available",
+			LastTransitionTime: metav1.NewTime(now.Add(-5 * time.Minute)),
+			LastHeartbeatTime:  metav1.NewTime(now.Add(-10 * time.Second)),
+		},
+		{
+			Type:               conditionsv1.ConditionDegraded,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.NewTime(now.Add(-50 * time.Hour)),
+		},
+	}
+)
+
+func TestPrintTable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  Options
+		expected string
+	}{
+		{
+			name:    "full messages",
+			options: Options{Now: now},
+			expected: `TYPE       STATUS  REASON      AGE   MESSAGE
+Available  True    AsExpected  5m    All components are available
+Degraded   False               2d2h
+`,
+		},
+		{
+			name:    "truncated messages",
+			options: Options{Now: now, MaxMessageWidth: 15},
+			expected: `TYPE       STATUS  REASON      AGE   MESSAGE
+Available  True    AsExpected  5m    All componen...
+Degraded   False               2d2h
+`,
+		},
+		{
+			name:    "wrapped messages",
+			options: Options{Now: now, MaxMessageWidth: 15, WrapMessages: true},
+			expected: `TYPE       STATUS  REASON      AGE   MESSAGE
+Available  True    AsExpected  5m    All components
+                                     are available
+Degraded   False               2d2h
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := PrintTable(buf, conditions, tc.options); err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Errorf("Unexpected output:\n%s\nexpected:\n%s", buf.String(), tc.expected)
+			}
+		})
+	}
+}
+
+func TestPrintDescribe(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := PrintDescribe(buf, conditions, Options{Now: now}); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	expected := `Type:                  Available
+Status:                True
+Reason:                AsExpected
+Message:               All components are available
+Last Transition Time:  2021-01-01T11:55:00Z (5m ago)
+Last Heartbeat Time:   2021-01-01T11:59:50Z (10s ago)
+
+Type:                  Degraded
+Status:                False
+Reason:
+Message:
+Last Transition Time:  2020-12-30T10:00:00Z (2d2h ago)
+Last Heartbeat Time:   <unknown>
+`
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	testCases := []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{s: "short", width: 10, expected: "short"},
+		{s: "exactly", width: 7, expected: "exactly"},
+		{s: "too long message", width: 10, expected: "too lon..."},
+		{s: "tiny", width: 2, expected: "ti"},
+		{s: "unlimited", width: 0, expected: "unlimited"},
+	}
+
+	for _, tc := range testCases {
+		if got := Truncate(tc.s, tc.width); got != tc.expected {
+			t.Errorf("Unexpected Truncate(%q, %d) '%v', expected '%v'", tc.s, tc.width, got, tc.expected)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	testCases := []struct {
+		s        string
+		width    int
+		expected []string
+	}{
+		{s: "", width: 5, expected: []string{""}},
+		{s: "a b c", width: 5, expected: []string{"a b c"}},
+		{s: "aaa bbb ccc", width: 7, expected: []string{"aaa bbb", "ccc"}},
+		{s: "aaaaaaaaaa b", width: 4, expected: []string{"aaaa", "aaaa", "aa b"}},
+	}
+
+	for _, tc := range testCases {
+		if got := wrap(tc.s, tc.width); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("Unexpected wrap(%q, %d) '%q', expected '%q'", tc.s, tc.width, got, tc.expected)
+		}
+	}
+}
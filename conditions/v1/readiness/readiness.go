@@ -0,0 +1,228 @@
+// Package readiness serves healthz and readyz style responses computed from
+// the conditions of in-memory objects, so the readiness probes of operator
+// pods reflect the status they report.
+package readiness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/openshift/custom-resource-status/conditions/v1/selector"
+)
+
+// DefaultSelector is the selector of the checks registered without one: ready
+// when Available is True and Degraded is not True.
+var DefaultSelector = selector.MustParse("Available=True,Degraded!=True")
+
+// ConditionsFunc returns the current conditions of a registered object. It is
+// called for every request, possibly concurrently, and must not block.
+type ConditionsFunc func() []conditionsv1.Condition
+
+// Result is the result of all the checks of a Handler.
+type Result struct {
+	Ready  bool          `json:"ready"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// CheckResult is the result of a single registered check.
+type CheckResult struct {
+	Name         string              `json:"name"`
+	Ready        bool                `json:"ready"`
+	Requirements []RequirementResult `json:"requirements,omitempty"`
+}
+
+// RequirementResult is the result of a single requirement of the selector of
+// a check, along with the condition it was evaluated against.
+type RequirementResult struct {
+	Requirement string `json:"requirement"`
+	Ready       bool   `json:"ready"`
+	// Condition is the condition of the requirement type, or nil when it is
+	// not present.
+	Condition *conditionsv1.Condition `json:"condition,omitempty"`
+}
+
+type check struct {
+	conditions ConditionsFunc
+	selector   selector.Selector
+}
+
+// Handler is an http.Handler serving the result of the checks registered on
+// it. Checks are evaluated on every request, in the order of their names.
+//
+// It responds with 200 and "ok" when all checks pass, and with 503 and the
+// verbose output otherwise. The verbose output, requested with the `verbose`
+// query parameter, lists every requirement of every check:
+//
+//	[+]operator Available=True ok
+//	[-]operator Degraded!=True failed: Degraded is True (Timeout: Timed out)
+//	readyz check failed
+//
+// where readyz is the name of the endpoint the handler is created for.
+//
+// The result is served as JSON, with the same status codes, when the `format`
+// query parameter is `json` or the request accepts application/json.
+type Handler struct {
+	name   string
+	lock   sync.RWMutex
+	checks map[string]check
+}
+
+var _ http.Handler = &Handler{}
+
+// NewHandler returns a Handler without checks, which is always ready, for the
+// endpoint named name, such as readyz or healthz.
+func NewHandler(name string) *Handler {
+	return &Handler{
+		name:   name,
+		checks: map[string]check{},
+	}
+}
+
+// Register adds a check named name, passing when sel matches the conditions
+// returned by conditions. DefaultSelector is used when sel is nil. A check
+// already registered with the same name is replaced.
+func (h *Handler) Register(name string, conditions ConditionsFunc, sel selector.Selector) {
+	if sel == nil {
+		sel = DefaultSelector
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.checks[name] = check{conditions: conditions, selector: sel}
+}
+
+// Unregister removes the check named name.
+func (h *Handler) Unregister(name string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.checks, name)
+}
+
+// Check evaluates all the registered checks.
+func (h *Handler) Check() Result {
+	h.lock.RLock()
+	names := make([]string, 0, len(h.checks))
+	checks := make(map[string]check, len(h.checks))
+	for name, c := range h.checks {
+		names = append(names, name)
+		checks[name] = c
+	}
+	h.lock.RUnlock()
+	sort.Strings(names)
+
+	result := Result{Ready: true, Checks: []CheckResult{}}
+	for _, name := range names {
+		checkResult := evaluate(name, checks[name])
+		result.Ready = result.Ready && checkResult.Ready
+		result.Checks = append(result.Checks, checkResult)
+	}
+	return result
+}
+
+func evaluate(name string, c check) CheckResult {
+	conditions := c.conditions()
+	result := CheckResult{Name: name, Ready: true}
+	for _, requirement := range c.selector.Requirements() {
+		requirementResult := RequirementResult{
+			Requirement: requirement.String(),
+			Ready:       requirement.Matches(conditions),
+		}
+		if condition := conditionsv1.FindStatusCondition(conditions, requirement.Type); condition != nil {
+			copied := *condition
+			requirementResult.Condition = &copied
+		}
+		result.Ready = result.Ready && requirementResult.Ready
+		result.Requirements = append(result.Requirements, requirementResult)
+	}
+	return result
+}
+
+// ServeHTTP serves the result of the registered checks.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	result := h.Check()
+	status := http.StatusOK
+	if !result.Ready {
+		status = http.StatusServiceUnavailable
+	}
+
+	if r.URL.Query().Get("format") == "json" || acceptsJSON(r) {
+		body, err := json.Marshal(result)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(body)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if _, verbose := r.URL.Query()["verbose"]; !verbose && result.Ready {
+		fmt.Fprint(w, "ok")
+		return
+	}
+	w.Write(verboseOutput(h.name, result))
+}
+
+// acceptsJSON returns true when the Accept header of r lists application/json
+// without a zero quality.
+func acceptsJSON(r *http.Request) bool {
+	for _, header := range r.Header["Accept"] {
+		for _, accepted := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+			if err == nil && mediaType == "application/json" && params["q"] != "0" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func verboseOutput(name string, result Result) []byte {
+	var buf bytes.Buffer
+	for _, checkResult := range result.Checks {
+		if len(checkResult.Requirements) == 0 {
+			fmt.Fprintf(&buf, "[+]%s ok\n", checkResult.Name)
+		}
+		for _, requirementResult := range checkResult.Requirements {
+			if requirementResult.Ready {
+				fmt.Fprintf(&buf, "[+]%s %s ok\n", checkResult.Name, requirementResult.Requirement)
+				continue
+			}
+			fmt.Fprintf(&buf, "[-]%s %s failed: %s\n", checkResult.Name, requirementResult.Requirement, describe(requirementResult.Condition))
+		}
+	}
+	if result.Ready {
+		fmt.Fprintf(&buf, "%s check passed\n", name)
+	} else {
+		fmt.Fprintf(&buf, "%s check failed\n", name)
+	}
+	return buf.Bytes()
+}
+
+// describe describes the condition a requirement failed on.
+func describe(condition *conditionsv1.Condition) string {
+	if condition == nil {
+		return "condition is not present"
+	}
+	description := fmt.Sprintf("%s is %s", condition.Type, condition.Status)
+	switch {
+	case condition.Reason != "" && condition.Message != "":
+		description = fmt.Sprintf("%s (%s: %s)", description, condition.Reason, condition.Message)
+	case condition.Reason != "":
+		description = fmt.Sprintf("%s (%s)", description, condition.Reason)
+	case condition.Message != "":
+		description = fmt.Sprintf("%s (%s)", description, condition.Message)
+	}
+	return description
+}
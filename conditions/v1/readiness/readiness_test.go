@@ -0,0 +1,207 @@
+package readiness
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/openshift/custom-resource-status/conditions/v1/selector"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func static(conditions ...conditionsv1.Condition) ConditionsFunc {
+	return func() []conditionsv1.Condition {
+		return conditions
+	}
+}
+
+var (
+	available   = conditionsv1.Condition{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue}
+	unavailable = conditionsv1.Condition{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionFalse, Reason: "NoEndpoints"}
+	degraded    = conditionsv1.Condition{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionTrue, Reason: "Timeout", Message: "Timed out"}
+	notDegraded = conditionsv1.Condition{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse}
+)
+
+func TestServeHTTP(t *testing.T) {
+	testCases := []struct {
+		name           string
+		endpoint       string
+		register       func(h *Handler)
+		target         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "no checks",
+			register:       func(h *Handler) {},
+			target:         "/readyz",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		{
+			name: "ready",
+			register: func(h *Handler) {
+				h.Register("operator", static(available, notDegraded), nil)
+			},
+			target:         "/readyz",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		{
+			name: "ready verbose",
+			register: func(h *Handler) {
+				h.Register("operator", static(available, notDegraded), nil)
+				h.Register("everything", static(), selector.Everything())
+			},
+			target:         "/readyz?verbose",
+			expectedStatus: http.StatusOK,
+			expectedBody: "[+]everything ok\n" +
+				"[+]operator Available=True ok\n" +
+				"[+]operator Degraded!=True ok\n" +
+				"readyz check passed\n",
+		},
+		{
+			name:     "healthz verbose",
+			endpoint: "healthz",
+			register: func(h *Handler) {
+				h.Register("operator", static(available, notDegraded), nil)
+			},
+			target:         "/healthz?verbose",
+			expectedStatus: http.StatusOK,
+			expectedBody: "[+]operator Available=True ok\n" +
+				"[+]operator Degraded!=True ok\n" +
+				"healthz check passed\n",
+		},
+		{
+			name: "not ready",
+			register: func(h *Handler) {
+				h.Register("operator", static(available, degraded), nil)
+				h.Register("operand", static(unavailable), nil)
+				h.Register("upgradeable", static(), selector.MustParse("Upgradeable=True"))
+			},
+			target:         "/readyz",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: "[-]operand Available=True failed: Available is False (NoEndpoints)\n" +
+				"[+]operand Degraded!=True ok\n" +
+				"[+]operator Available=True ok\n" +
+				"[-]operator Degraded!=True failed: Degraded is True (Timeout: Timed out)\n" +
+				"[-]upgradeable Upgradeable=True failed: condition is not present\n" +
+				"readyz check failed\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint := tc.endpoint
+			if endpoint == "" {
+				endpoint = "readyz"
+			}
+			handler := NewHandler(endpoint)
+			tc.register(handler)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+			if recorder.Code != tc.expectedStatus {
+				t.Errorf("Unexpected status '%v', expected '%v'", recorder.Code, tc.expectedStatus)
+			}
+			if body := recorder.Body.String(); body != tc.expectedBody {
+				t.Errorf("Unexpected body '%v', expected '%v'", body, tc.expectedBody)
+			}
+		})
+	}
+}
+
+func TestServeHTTPJSON(t *testing.T) {
+	handler := NewHandler("readyz")
+	handler.Register("operator", static(available, degraded), nil)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	for _, request := range []func() (*http.Response, error){
+		func() (*http.Response, error) { return http.Get(server.URL + "/readyz?format=json") },
+		func() (*http.Response, error) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/readyz", nil)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Accept", "application/json")
+			return http.DefaultClient.Do(req)
+		},
+	} {
+		resp, err := request()
+		if err != nil {
+			t.Fatalf("Error occurred unexpectedly: %v", err)
+		}
+		result := Result{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Error occurred unexpectedly: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Unexpected status '%v', expected '%v'", resp.StatusCode, http.StatusServiceUnavailable)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("Unexpected content type '%v', expected 'application/json'", contentType)
+		}
+		if result.Ready || len(result.Checks) != 1 || result.Checks[0].Name != "operator" || result.Checks[0].Ready {
+			t.Fatalf("Unexpected result '%+v', expected the operator check to fail", result)
+		}
+		requirements := result.Checks[0].Requirements
+		if len(requirements) != 2 || !requirements[0].Ready || requirements[1].Ready {
+			t.Fatalf("Unexpected requirements '%+v', expected only Degraded!=True to fail", requirements)
+		}
+		if requirements[1].Requirement != "Degraded!=True" || requirements[1].Condition == nil || requirements[1].Condition.Reason != "Timeout" {
+			t.Errorf("Unexpected requirement '%+v', expected Degraded!=True with the Degraded condition", requirements[1])
+		}
+	}
+}
+
+func TestAcceptsJSON(t *testing.T) {
+	testCases := []struct {
+		accept   []string
+		expected bool
+	}{
+		{accept: nil, expected: false},
+		{accept: []string{"application/json"}, expected: true},
+		{accept: []string{"application/json, */*"}, expected: true},
+		{accept: []string{"text/html", "application/json; q=0.9"}, expected: true},
+		{accept: []string{"application/json;q=0"}, expected: false},
+		{accept: []string{"text/plain, */*"}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		req.Header["Accept"] = tc.accept
+		if accepts := acceptsJSON(req); accepts != tc.expected {
+			t.Errorf("Unexpected accepts JSON '%v' for '%v', expected '%v'", accepts, tc.accept, tc.expected)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	conditions := []conditionsv1.Condition{unavailable}
+	handler := NewHandler("readyz")
+	handler.Register("operator", func() []conditionsv1.Condition { return conditions }, nil)
+
+	if handler.Check().Ready {
+		t.Errorf("Unexpected ready 'true', expected 'false'")
+	}
+	conditionsv1.SetStatusCondition(&conditions, available)
+	if !handler.Check().Ready {
+		t.Errorf("Unexpected ready 'false', expected the updated conditions to be ready")
+	}
+
+	handler.Register("operator", static(unavailable), nil)
+	if result := handler.Check(); result.Ready || len(result.Checks) != 1 {
+		t.Errorf("Unexpected result '%+v', expected the check to be replaced", result)
+	}
+	handler.Unregister("operator")
+	if result := handler.Check(); !result.Ready || len(result.Checks) != 0 {
+		t.Errorf("Unexpected result '%+v', expected no checks", result)
+	}
+}
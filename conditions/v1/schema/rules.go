@@ -0,0 +1,21 @@
+package schema
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ValidationRules returns the x-kubernetes-validations CEL rules enforcing the
+// invariants of a condition, to set on the items of the schema returned by
+// Conditions. Each rule then validates a single condition, keeping its cost
+// independent of the length of the list. The reason pattern and the
+// uniqueness of condition types are already enforced by the schema, so they
+// have no rule. The rules require a cluster with the
+// CustomResourceValidationExpressions feature enabled.
+func ValidationRules() apiextensionsv1.ValidationRules {
+	return apiextensionsv1.ValidationRules{
+		{
+			Rule:    "has(self.lastTransitionTime) && type(self.lastTransitionTime) != null_type",
+			Message: "lastTransitionTime must be set",
+		},
+	}
+}
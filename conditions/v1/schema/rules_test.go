@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/listtype"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidationRules(t *testing.T) {
+	conditions := Conditions()
+	conditions.Items.Schema.XValidations = ValidationRules()
+	status := apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"conditions": conditions,
+		},
+	}
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&status, internal, nil); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	structural, err := structuralschema.NewStructural(internal)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	validator := cel.NewValidator(structural)
+
+	condition := func(conditionType, reason string, lastTransitionTime interface{}) map[string]interface{} {
+		c := map[string]interface{}{"type": conditionType, "status": "True"}
+		if reason != "" {
+			c["reason"] = reason
+		}
+		if lastTransitionTime != "" {
+			c["lastTransitionTime"] = lastTransitionTime
+		}
+		return c
+	}
+	const timestamp = "2021-01-01T12:00:00Z"
+
+	testCases := []struct {
+		name           string
+		conditions     []interface{}
+		expectedErrors []string
+	}{
+		{
+			name: "valid",
+			conditions: []interface{}{
+				condition("Available", "AsExpected", timestamp),
+				condition("Degraded", "", timestamp),
+			},
+		},
+		{
+			name:       "empty",
+			conditions: []interface{}{},
+		},
+		{
+			name: "missing lastTransitionTime",
+			conditions: []interface{}{
+				condition("Available", "AsExpected", ""),
+			},
+			expectedErrors: []string{"status.conditions[0]: lastTransitionTime must be set"},
+		},
+		{
+			name: "null lastTransitionTime",
+			conditions: []interface{}{
+				condition("Available", "AsExpected", nil),
+			},
+			expectedErrors: []string{"status.conditions[0]: lastTransitionTime must be set"},
+		},
+		{
+			name: "missing lastTransitionTime in a later condition",
+			conditions: []interface{}{
+				condition("Available", "AsExpected", timestamp),
+				condition("Degraded", "AsExpected", ""),
+			},
+			expectedErrors: []string{"status.conditions[1]: lastTransitionTime must be set"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			object := map[string]interface{}{"conditions": tc.conditions}
+			errs := validator.Validate(field.NewPath("status"), structural, object)
+			if len(errs) != len(tc.expectedErrors) {
+				t.Fatalf("Unexpected errors '%v', expected '%v'", errs, tc.expectedErrors)
+			}
+			for i, err := range errs {
+				if err.Field+": "+err.Detail != tc.expectedErrors[i] {
+					t.Errorf("Unexpected error '%v', expected '%v'", err, tc.expectedErrors[i])
+				}
+			}
+		})
+	}
+}
+
+// TestConditionsUniqueTypes checks that the map list type of Conditions makes
+// the API server reject duplicate condition types without a rule.
+func TestConditionsUniqueTypes(t *testing.T) {
+	status := apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"conditions": Conditions(),
+		},
+	}
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&status, internal, nil); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	structural, err := structuralschema.NewStructural(internal)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+
+	object := map[string]interface{}{"conditions": []interface{}{
+		map[string]interface{}{"type": "Available", "status": "True"},
+		map[string]interface{}{"type": "Available", "status": "False"},
+	}}
+	errs := listtype.ValidateListSetsAndMaps(field.NewPath("status"), structural, object)
+	if len(errs) != 1 || errs[0].Type != field.ErrorTypeDuplicate || errs[0].Field != "status.conditions[1]" {
+		t.Errorf("Unexpected errors '%v', expected a duplicate status.conditions[1]", errs)
+	}
+}
@@ -0,0 +1,79 @@
+// Package schema provides the apiextensions.k8s.io/v1 structural schemas of
+// conditions, to embed or merge into the CustomResourceDefinitions of custom
+// resources with a Conditions field.
+package schema
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ReasonPattern is the pattern of the reason of a condition, as in the
+// validation marker of conditionsv1.Condition.
+const ReasonPattern = `^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`
+
+// Conditions returns the schema of a []conditionsv1.Condition field. The list
+// is a map keyed on the type of the conditions.
+func Conditions() apiextensionsv1.JSONSchemaProps {
+	condition := Condition()
+	listType := "map"
+	return apiextensionsv1.JSONSchemaProps{
+		Description:  "conditions describes the state of the operator's reconciliation functionality.",
+		Type:         "array",
+		Items:        &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &condition},
+		XListType:    &listType,
+		XListMapKeys: []string{"type"},
+	}
+}
+
+// Condition returns the schema of a conditionsv1.Condition, with the
+// constraints of its validation markers. The tests of conditionsv1 check that
+// it matches the schema controller-gen generates from those markers.
+func Condition() apiextensionsv1.JSONSchemaProps {
+	typeMaxLength, reasonMaxLength, messageMaxLength := int64(316), int64(1024), int64(32768)
+	return apiextensionsv1.JSONSchemaProps{
+		Description: "Condition represents the state of the operator's reconciliation functionality.",
+		Type:        "object",
+		Required:    []string{"type", "status"},
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"type": {
+				Description: "type of condition ie. Available|Progressing|Degraded.",
+				Type:        "string",
+				Pattern:     `^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`,
+				MaxLength:   &typeMaxLength,
+			},
+			"status": {
+				Description: "status of the condition, one of True, False, Unknown.",
+				Type:        "string",
+				Enum: []apiextensionsv1.JSON{
+					{Raw: []byte(`"True"`)},
+					{Raw: []byte(`"False"`)},
+					{Raw: []byte(`"Unknown"`)},
+				},
+			},
+			"reason": {
+				Description: "one-word CamelCase reason for the condition's last transition.",
+				Type:        "string",
+				Pattern:     ReasonPattern,
+				MaxLength:   &reasonMaxLength,
+			},
+			"message": {
+				Description: "human-readable message indicating details about last transition.",
+				Type:        "string",
+				MaxLength:   &messageMaxLength,
+			},
+			// The timestamps are serialized as null when unset.
+			"lastHeartbeatTime": {
+				Description: "last time we got an update on a given condition.",
+				Type:        "string",
+				Format:      "date-time",
+				Nullable:    true,
+			},
+			"lastTransitionTime": {
+				Description: "last time the condition transit from one status to another.",
+				Type:        "string",
+				Format:      "date-time",
+				Nullable:    true,
+			},
+		},
+	}
+}
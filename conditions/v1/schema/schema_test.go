@@ -0,0 +1,146 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// statusValidator returns a validator of a status object embedding the
+// conditions schema, after checking the schema is structural.
+func statusValidator(t *testing.T) func(interface{}) bool {
+	t.Helper()
+	status := apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"conditions": Conditions(),
+		},
+	}
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&status, internal, nil); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	structural, err := structuralschema.NewStructural(internal)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	if errs := structuralschema.ValidateStructural(field.NewPath("status"), structural); len(errs) > 0 {
+		t.Fatalf("Unexpected non-structural schema: %v", errs.ToAggregate())
+	}
+	validator, _, err := validation.NewSchemaValidator(&apiextensions.CustomResourceValidation{OpenAPIV3Schema: internal})
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	return func(object interface{}) bool {
+		return validator.Validate(object).IsValid()
+	}
+}
+
+func TestConditions(t *testing.T) {
+	validate := statusValidator(t)
+
+	testCases := []struct {
+		name          string
+		conditions    []interface{}
+		expectedValid bool
+	}{
+		{
+			name: "valid",
+			conditions: []interface{}{
+				map[string]interface{}{
+					"type":               "Available",
+					"status":             "True",
+					"reason":             "AsExpected",
+					"message":            "All components are available",
+					"lastHeartbeatTime":  "2021-01-01T12:00:00Z",
+					"lastTransitionTime": "2021-01-01T11:00:00Z",
+				},
+				map[string]interface{}{
+					"type":               "example.com/Degraded",
+					"status":             "Unknown",
+					"lastHeartbeatTime":  nil,
+					"lastTransitionTime": nil,
+				},
+			},
+			expectedValid: true,
+		},
+		{
+			name:          "empty",
+			conditions:    []interface{}{},
+			expectedValid: true,
+		},
+		{
+			name: "invalid status",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Available", "status": "Yes"},
+			},
+			expectedValid: false,
+		},
+		{
+			name: "missing status",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Available"},
+			},
+			expectedValid: false,
+		},
+		{
+			name: "missing type",
+			conditions: []interface{}{
+				map[string]interface{}{"status": "True"},
+			},
+			expectedValid: false,
+		},
+		{
+			name: "invalid type",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Not Available", "status": "True"},
+			},
+			expectedValid: false,
+		},
+		{
+			name: "invalid reason",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True", "reason": "As expected"},
+			},
+			expectedValid: false,
+		},
+		{
+			name: "too long message",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True", "message": strings.Repeat("a", 32769)},
+			},
+			expectedValid: false,
+		},
+		{
+			name: "invalid timestamp",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True", "lastTransitionTime": "yesterday"},
+			},
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			valid := validate(map[string]interface{}{"conditions": tc.conditions})
+			if valid != tc.expectedValid {
+				t.Errorf("Unexpected validation result: expected: %t; actual: %t", tc.expectedValid, valid)
+			}
+		})
+	}
+}
+
+func TestConditionsListMap(t *testing.T) {
+	conditions := Conditions()
+	if conditions.XListType == nil || *conditions.XListType != "map" {
+		t.Errorf("Unexpected x-kubernetes-list-type '%v', expected 'map'", conditions.XListType)
+	}
+	if len(conditions.XListMapKeys) != 1 || conditions.XListMapKeys[0] != "type" {
+		t.Errorf("Unexpected x-kubernetes-list-map-keys '%v', expected '[type]'", conditions.XListMapKeys)
+	}
+}
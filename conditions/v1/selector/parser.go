@@ -0,0 +1,293 @@
+package selector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+)
+
+// Parse parses a selector. An empty string returns a selector matching
+// everything. See the package documentation for the syntax.
+func Parse(selector string) (Selector, error) {
+	p := &parser{input: selector}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+	reqs := []Requirement{}
+	if p.peek().kind == tokenEnd {
+		return requirements(reqs), nil
+	}
+	for {
+		r, err := p.parseRequirement()
+		if err != nil {
+			return nil, err
+		}
+		if err := validate(r); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, r)
+
+		switch t := p.next(); t.kind {
+		case tokenEnd:
+			return requirements(reqs), nil
+		case tokenComma:
+		default:
+			return nil, p.errorf(t, "expected ',' or end of selector")
+		}
+	}
+}
+
+// MustParse is like Parse but panics on error. It is meant for selectors
+// known at compile time.
+func MustParse(selector string) Selector {
+	s, err := Parse(selector)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenComma
+	tokenOpenParen
+	tokenCloseParen
+	tokenEquals
+	tokenNotEquals
+	tokenNot
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	// position is the offset of the token in the input.
+	position int
+}
+
+type parser struct {
+	input    string
+	tokens   []token
+	position int
+}
+
+// isIdentifierRune returns true for the runes allowed in condition types,
+// reasons and statuses. Reasons containing a comma must be quoted.
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-./:", r)
+}
+
+// isIdentifier returns true when value can be written without quotes.
+func isIdentifier(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if !isIdentifierRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) lex() error {
+	runes := []rune(p.input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == ',':
+			p.tokens = append(p.tokens, token{kind: tokenComma, value: ",", position: i})
+			i++
+		case r == '(':
+			p.tokens = append(p.tokens, token{kind: tokenOpenParen, value: "(", position: i})
+			i++
+		case r == ')':
+			p.tokens = append(p.tokens, token{kind: tokenCloseParen, value: ")", position: i})
+			i++
+		case r == '=':
+			t := token{kind: tokenEquals, value: "=", position: i}
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				t.value = "=="
+				i++
+			}
+			p.tokens = append(p.tokens, t)
+		case r == '!':
+			t := token{kind: tokenNot, value: "!", position: i}
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				t = token{kind: tokenNotEquals, value: "!=", position: t.position}
+				i++
+			}
+			p.tokens = append(p.tokens, t)
+		case isIdentifierRune(r):
+			start := i
+			for i < len(runes) && isIdentifierRune(runes[i]) {
+				i++
+			}
+			p.tokens = append(p.tokens, token{kind: tokenIdentifier, value: string(runes[start:i]), position: start})
+		case r == '"':
+			start := i
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return fmt.Errorf("invalid selector %q: unterminated string at position %d", p.input, start)
+			}
+			i++
+			value, err := strconv.Unquote(string(runes[start:i]))
+			if err != nil {
+				return fmt.Errorf("invalid selector %q: invalid string at position %d: %v", p.input, start, err)
+			}
+			p.tokens = append(p.tokens, token{kind: tokenString, value: value, position: start})
+		default:
+			return fmt.Errorf("invalid selector %q: unexpected character %q at position %d", p.input, r, i)
+		}
+	}
+	p.tokens = append(p.tokens, token{kind: tokenEnd, position: len(runes)})
+	return nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.position]
+	if t.kind != tokenEnd {
+		p.position++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	found := t.value
+	if t.kind == tokenEnd {
+		found = "end of selector"
+	}
+	return fmt.Errorf("invalid selector %q: %s at position %d, found %q", p.input, fmt.Sprintf(format, args...), t.position, found)
+}
+
+func (p *parser) expect(kind tokenKind, description string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, p.errorf(t, "expected %s", description)
+	}
+	return t, nil
+}
+
+// expectValue returns the next token when it is a value, an identifier or a
+// quoted string.
+func (p *parser) expectValue() (token, error) {
+	t := p.next()
+	if t.kind != tokenIdentifier && t.kind != tokenString {
+		return t, p.errorf(t, "expected value")
+	}
+	return t, nil
+}
+
+// parseRequirement parses:
+//
+//	['!'] subject
+//	subject ('=' | '==' | '!=') value
+//	subject ('in' | 'notin') '(' value {',' value} ')'
+//
+// where subject is TYPE or FIELD '(' TYPE ')', and value is an identifier or a
+// quoted string. 'in' and 'notin' are only keywords after a subject, so they
+// remain valid condition types and values.
+func (p *parser) parseRequirement() (Requirement, error) {
+	r := Requirement{Field: FieldStatus}
+	negated := false
+	if p.peek().kind == tokenNot {
+		p.next()
+		negated = true
+	}
+
+	t, err := p.expect(tokenIdentifier, "condition type")
+	if err != nil {
+		return r, err
+	}
+	r.Type = conditionsv1.ConditionType(t.value)
+	if p.peek().kind == tokenOpenParen {
+		field := Field(t.value)
+		if field != FieldStatus && field != FieldReason {
+			return r, p.errorf(t, "expected field status or reason")
+		}
+		p.next()
+		if t, err = p.expect(tokenIdentifier, "condition type"); err != nil {
+			return r, err
+		}
+		if _, err := p.expect(tokenCloseParen, "')'"); err != nil {
+			return r, err
+		}
+		r.Field = field
+		r.Type = conditionsv1.ConditionType(t.value)
+	}
+
+	if negated {
+		r.Operator = DoesNotExist
+		return r, nil
+	}
+
+	switch t := p.peek(); t.kind {
+	case tokenEquals, tokenNotEquals:
+		p.next()
+		r.Operator = Equals
+		if t.kind == tokenNotEquals {
+			r.Operator = NotEquals
+		}
+		value, err := p.expectValue()
+		if err != nil {
+			return r, err
+		}
+		r.Values = []string{value.value}
+	case tokenIdentifier:
+		p.next()
+		switch t.value {
+		case "in":
+			r.Operator = In
+		case "notin":
+			r.Operator = NotIn
+		default:
+			return r, p.errorf(t, "expected operator")
+		}
+		if r.Values, err = p.parseValues(); err != nil {
+			return r, err
+		}
+	default:
+		r.Operator = Exists
+	}
+	return r, nil
+}
+
+// parseValues parses '(' value {',' value} ')'.
+func (p *parser) parseValues() ([]string, error) {
+	if _, err := p.expect(tokenOpenParen, "'('"); err != nil {
+		return nil, err
+	}
+	values := []string{}
+	for {
+		value, err := p.expectValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value.value)
+		switch t := p.next(); t.kind {
+		case tokenCloseParen:
+			return values, nil
+		case tokenComma:
+		default:
+			return nil, p.errorf(t, "expected ',' or ')'")
+		}
+	}
+}
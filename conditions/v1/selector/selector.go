@@ -0,0 +1,254 @@
+// Package selector parses condition selectors and matches them against a
+// slice of conditions, like k8s.io/apimachinery/pkg/labels does for labels.
+//
+// A selector is a comma-separated list of requirements, all of which must
+// hold for the selector to match:
+//
+//	Available=True                  Available is present and True
+//	Degraded!=True                  Degraded is absent or not True
+//	Progressing in (True,Unknown)   Progressing is present and True or Unknown
+//	Progressing notin (True)        Progressing is absent or not True
+//	Upgradeable                     Upgradeable is present
+//	!Upgradeable                    Upgradeable is absent
+//	reason(Degraded)=Timeout        Degraded is present with the Timeout reason
+//	reason(Degraded)="Failed,Retry" Degraded is present with the Failed,Retry reason
+//
+// The reason(TYPE) form accepts the same operators as TYPE, except for the
+// existence checks. status(TYPE) is equivalent to TYPE. Values containing a
+// comma, or any rune not allowed in condition types besides a colon, are
+// double-quoted with the escapes of Go string literals.
+package selector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Selector matches a slice of conditions.
+type Selector interface {
+	// Matches returns true when all the requirements of the selector hold
+	// for conditions.
+	Matches(conditions []conditionsv1.Condition) bool
+
+	// Empty returns true when the selector has no requirements and matches
+	// everything.
+	Empty() bool
+
+	// Requirements returns the requirements of the selector.
+	Requirements() []Requirement
+
+	// String returns the selector in the canonical form accepted by Parse.
+	String() string
+}
+
+// Field is the field of a condition a requirement is evaluated against.
+type Field string
+
+const (
+	// FieldStatus evaluates the status of the condition.
+	FieldStatus Field = "status"
+
+	// FieldReason evaluates the reason of the condition.
+	FieldReason Field = "reason"
+)
+
+// Operator is the operator of a requirement.
+type Operator string
+
+const (
+	// Equals requires the condition to be present with the value.
+	Equals Operator = "="
+	// NotEquals requires the condition to be absent or to have another value.
+	NotEquals Operator = "!="
+	// In requires the condition to be present with one of the values.
+	In Operator = "in"
+	// NotIn requires the condition to be absent or to have none of the values.
+	NotIn Operator = "notin"
+	// Exists requires the condition to be present.
+	Exists Operator = "exists"
+	// DoesNotExist requires the condition to be absent.
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single requirement of a selector.
+type Requirement struct {
+	Field    Field
+	Type     conditionsv1.ConditionType
+	Operator Operator
+	// Values holds a single value for Equals and NotEquals, one or more for In
+	// and NotIn, and none for Exists and DoesNotExist.
+	Values []string
+}
+
+// Matches returns true when the requirement holds for conditions.
+// Equals is evaluated with conditionsv1.IsStatusConditionPresentAndEqual for
+// the status field, so it never holds for an absent condition, and NotEquals
+// and NotIn always hold for an absent condition.
+func (r Requirement) Matches(conditions []conditionsv1.Condition) bool {
+	condition := conditionsv1.FindStatusCondition(conditions, r.Type)
+	switch r.Operator {
+	case Exists:
+		return condition != nil
+	case DoesNotExist:
+		return condition == nil
+	case Equals:
+		if r.Field == FieldStatus {
+			return conditionsv1.IsStatusConditionPresentAndEqual(conditions, r.Type, corev1.ConditionStatus(r.Values[0]))
+		}
+		return condition != nil && r.value(condition) == r.Values[0]
+	case NotEquals:
+		return !Requirement{Field: r.Field, Type: r.Type, Operator: Equals, Values: r.Values}.Matches(conditions)
+	case In:
+		return condition != nil && r.hasValue(r.value(condition))
+	case NotIn:
+		return condition == nil || !r.hasValue(r.value(condition))
+	default:
+		return false
+	}
+}
+
+func (r Requirement) value(condition *conditionsv1.Condition) string {
+	if r.Field == FieldReason {
+		return condition.Reason
+	}
+	return string(condition.Status)
+}
+
+func (r Requirement) hasValue(value string) bool {
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the requirement in the canonical form accepted by Parse.
+func (r Requirement) String() string {
+	subject := string(r.Type)
+	if r.Field == FieldReason {
+		subject = fmt.Sprintf("%s(%s)", r.Field, r.Type)
+	}
+	switch r.Operator {
+	case Exists:
+		return subject
+	case DoesNotExist:
+		return "!" + subject
+	case In, NotIn:
+		values := make([]string, len(r.Values))
+		for i, value := range r.Values {
+			values[i] = quote(value)
+		}
+		return fmt.Sprintf("%s %s (%s)", subject, r.Operator, strings.Join(values, ","))
+	default:
+		values := ""
+		if len(r.Values) > 0 {
+			values = quote(r.Values[0])
+		}
+		return subject + string(r.Operator) + values
+	}
+}
+
+// quote returns value as written in a selector, quoted unless it is an
+// identifier.
+func quote(value string) string {
+	if isIdentifier(value) {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// requirements is the Selector implementation.
+type requirements []Requirement
+
+// Everything returns a selector matching all conditions.
+func Everything() Selector {
+	return requirements{}
+}
+
+func (rs requirements) Matches(conditions []conditionsv1.Condition) bool {
+	for _, r := range rs {
+		if !r.Matches(conditions) {
+			return false
+		}
+	}
+	return true
+}
+
+func (rs requirements) Empty() bool {
+	return len(rs) == 0
+}
+
+func (rs requirements) Requirements() []Requirement {
+	return append([]Requirement{}, rs...)
+}
+
+func (rs requirements) String() string {
+	terms := make([]string, len(rs))
+	for i, r := range rs {
+		terms[i] = r.String()
+	}
+	return strings.Join(terms, ",")
+}
+
+// NewSelector returns a selector of the provided requirements after
+// validating them.
+func NewSelector(reqs ...Requirement) (Selector, error) {
+	for _, r := range reqs {
+		if err := validate(r); err != nil {
+			return nil, err
+		}
+	}
+	return requirements(append([]Requirement{}, reqs...)), nil
+}
+
+func validate(r Requirement) error {
+	if r.Type == "" {
+		return fmt.Errorf("invalid requirement %q: missing condition type", r)
+	}
+	if r.Field != FieldStatus && r.Field != FieldReason {
+		return fmt.Errorf("invalid requirement %q: unknown field %q", r, r.Field)
+	}
+	switch r.Operator {
+	case Exists, DoesNotExist:
+		if r.Field != FieldStatus {
+			return fmt.Errorf("invalid requirement %q: %s does not support existence checks", r, r.Field)
+		}
+		if len(r.Values) != 0 {
+			return fmt.Errorf("invalid requirement %q: existence checks take no value", r)
+		}
+		return nil
+	case Equals, NotEquals:
+		if len(r.Values) != 1 {
+			return fmt.Errorf("invalid requirement %q: %s takes exactly one value", r, r.Operator)
+		}
+	case In, NotIn:
+		if len(r.Values) == 0 {
+			return fmt.Errorf("invalid requirement %q: %s takes at least one value", r, r.Operator)
+		}
+	default:
+		return fmt.Errorf("invalid requirement %q: unknown operator %q", r, r.Operator)
+	}
+	for _, value := range r.Values {
+		if value == "" {
+			return fmt.Errorf("invalid requirement %q: empty value", r)
+		}
+		if r.Field == FieldStatus && !isStatus(value) {
+			return fmt.Errorf("invalid requirement %q: status must be one of True, False, Unknown", r)
+		}
+	}
+	return nil
+}
+
+func isStatus(value string) bool {
+	switch corev1.ConditionStatus(value) {
+	case corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown:
+		return true
+	default:
+		return false
+	}
+}
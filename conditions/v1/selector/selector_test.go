@@ -0,0 +1,141 @@
+package selector
+
+import (
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var conditions = []conditionsv1.Condition{
+	{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue, Reason: "AsExpected"},
+	{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionTrue, Reason: "Timeout"},
+	{Type: conditionsv1.ConditionProgressing, Status: corev1.ConditionUnknown},
+	{Type: "Scaled", Status: corev1.ConditionFalse, Reason: "Failed,Retry"},
+	{Type: "in", Status: corev1.ConditionTrue, Reason: "Scale:Up_"},
+}
+
+func TestParseAndMatches(t *testing.T) {
+	testCases := []struct {
+		selector        string
+		expectedString  string
+		expectedMatches bool
+	}{
+		{selector: "", expectedString: "", expectedMatches: true},
+		{selector: "Available=True", expectedString: "Available=True", expectedMatches: true},
+		{selector: "Available==True", expectedString: "Available=True", expectedMatches: true},
+		{selector: "Available=False", expectedString: "Available=False", expectedMatches: false},
+		{selector: "Upgradeable=True", expectedString: "Upgradeable=True", expectedMatches: false},
+		{selector: "Degraded!=True", expectedString: "Degraded!=True", expectedMatches: false},
+		{selector: "Upgradeable!=True", expectedString: "Upgradeable!=True", expectedMatches: true},
+		{selector: "Progressing in (True,Unknown)", expectedString: "Progressing in (True,Unknown)", expectedMatches: true},
+		{selector: "Upgradeable in (True, Unknown)", expectedString: "Upgradeable in (True,Unknown)", expectedMatches: false},
+		{selector: "Progressing notin (True)", expectedString: "Progressing notin (True)", expectedMatches: true},
+		{selector: "Upgradeable notin (True)", expectedString: "Upgradeable notin (True)", expectedMatches: true},
+		{selector: "Available", expectedString: "Available", expectedMatches: true},
+		{selector: "!Upgradeable", expectedString: "!Upgradeable", expectedMatches: true},
+		{selector: "!Available", expectedString: "!Available", expectedMatches: false},
+		{selector: "reason(Degraded)=Timeout", expectedString: "reason(Degraded)=Timeout", expectedMatches: true},
+		{selector: "reason(Degraded)!=Timeout", expectedString: "reason(Degraded)!=Timeout", expectedMatches: false},
+		{selector: "reason(Upgradeable)!=Timeout", expectedString: "reason(Upgradeable)!=Timeout", expectedMatches: true},
+		{selector: "reason(Available) in (AsExpected,Ready)", expectedString: "reason(Available) in (AsExpected,Ready)", expectedMatches: true},
+		{selector: "status(Available)=True", expectedString: "Available=True", expectedMatches: true},
+		{selector: " Available=True , Degraded!=True ", expectedString: "Available=True,Degraded!=True", expectedMatches: false},
+		{selector: "Available=True,!Upgradeable,reason(Degraded)=Timeout", expectedString: "Available=True,!Upgradeable,reason(Degraded)=Timeout", expectedMatches: true},
+		{selector: "example.com/Ready=True", expectedString: "example.com/Ready=True", expectedMatches: false},
+		{selector: `reason(Scaled)="Failed,Retry"`, expectedString: `reason(Scaled)="Failed,Retry"`, expectedMatches: true},
+		{selector: `reason(Scaled) in ("Failed,Retry",Failed)`, expectedString: `reason(Scaled) in ("Failed,Retry",Failed)`, expectedMatches: true},
+		{selector: `reason(Scaled)="Failed"`, expectedString: "reason(Scaled)=Failed", expectedMatches: false},
+		{selector: "reason(in)=Scale:Up_", expectedString: "reason(in)=Scale:Up_", expectedMatches: true},
+		{selector: "reason(Degraded)!=asExpected", expectedString: "reason(Degraded)!=asExpected", expectedMatches: true},
+		{selector: "in in (True)", expectedString: "in in (True)", expectedMatches: true},
+		{selector: "in,!notin", expectedString: "in,!notin", expectedMatches: true},
+		{selector: "reason(Available) notin (in,notin)", expectedString: "reason(Available) notin (in,notin)", expectedMatches: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.selector, func(t *testing.T) {
+			s, err := Parse(tc.selector)
+			if err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+			if s.String() != tc.expectedString {
+				t.Errorf("Unexpected String() '%v', expected '%v'", s.String(), tc.expectedString)
+			}
+			if matches := s.Matches(conditions); matches != tc.expectedMatches {
+				t.Errorf("Unexpected Matches(): expected: %t; actual: %t", tc.expectedMatches, matches)
+			}
+
+			// The canonical form must parse to the same selector.
+			reparsed, err := Parse(s.String())
+			if err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+			if reparsed.String() != s.String() {
+				t.Errorf("Unexpected reparsed selector '%v', expected '%v'", reparsed, s)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []string{
+		"Available=",
+		"Available=Yes",
+		"Available in ()",
+		"Available in (True",
+		"Available in True",
+		"Available=True,",
+		",Available=True",
+		"Available True",
+		"!reason(Degraded)",
+		"reason(Degraded)",
+		"message(Degraded)=Foo",
+		"reason(Degraded=Foo",
+		"Available=True;Degraded=False",
+		"Available within (True)",
+		`reason(Degraded)="Timeout`,
+		`reason(Degraded)="\q"`,
+		`"Available"=True`,
+		"!",
+		"=True",
+	}
+
+	for _, selector := range testCases {
+		t.Run(selector, func(t *testing.T) {
+			if _, err := Parse(selector); err == nil {
+				t.Errorf("Expected an error parsing %q", selector)
+			}
+		})
+	}
+}
+
+func TestNewSelector(t *testing.T) {
+	s, err := NewSelector(
+		Requirement{Field: FieldStatus, Type: conditionsv1.ConditionAvailable, Operator: Equals, Values: []string{"True"}},
+		Requirement{Field: FieldReason, Type: conditionsv1.ConditionDegraded, Operator: In, Values: []string{"Timeout", "Crash"}},
+	)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	if expected := "Available=True,reason(Degraded) in (Timeout,Crash)"; s.String() != expected {
+		t.Errorf("Unexpected String() '%v', expected '%v'", s.String(), expected)
+	}
+	if !s.Matches(conditions) {
+		t.Error("Expected the selector to match")
+	}
+	if len(s.Requirements()) != 2 || s.Empty() {
+		t.Errorf("Unexpected requirements '%v'", s.Requirements())
+	}
+
+	_, err = NewSelector(Requirement{Field: FieldStatus, Type: conditionsv1.ConditionAvailable, Operator: Equals})
+	if err == nil {
+		t.Error("Expected an error for a requirement without value")
+	}
+}
+
+func TestEverything(t *testing.T) {
+	if !Everything().Empty() || !Everything().Matches(nil) {
+		t.Error("Expected Everything to be empty and to match")
+	}
+}
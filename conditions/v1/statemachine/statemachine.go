@@ -0,0 +1,160 @@
+// Package statemachine declares the status transitions allowed for condition
+// types, and enforces them when setting conditions or validating updates.
+//
+// For example, to only let Upgradeable become True again once the blocking
+// upgrade precondition was cleared:
+//
+//	machines := statemachine.Machines{
+//		conditionsv1.ConditionUpgradeable: {
+//			Transitions: []statemachine.Transition{
+//				{From: corev1.ConditionTrue, To: corev1.ConditionFalse},
+//				{From: corev1.ConditionFalse, To: corev1.ConditionTrue, Reasons: []string{"PreconditionCleared"}},
+//			},
+//		},
+//	}
+package statemachine
+
+import (
+	"fmt"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Transition is a status change allowed by a Machine.
+type Transition struct {
+	From corev1.ConditionStatus
+	To   corev1.ConditionStatus
+
+	// Reasons restricts the reason of the condition after the transition.
+	// Any reason is allowed when empty.
+	Reasons []string
+}
+
+// Machine declares the statuses a condition type can be added with and the
+// status changes it can go through. Updates keeping the status, for example
+// to change the reason or message, are always allowed.
+type Machine struct {
+	// Initial restricts the statuses the condition can be added with. Any
+	// status is allowed when empty.
+	Initial []corev1.ConditionStatus
+
+	// Transitions lists the allowed status changes. Changes not listed are
+	// disallowed.
+	Transitions []Transition
+}
+
+// Machines maps condition types to their Machine. Condition types not present
+// are not restricted.
+type Machines map[conditionsv1.ConditionType]Machine
+
+// TransitionError is returned for a disallowed transition.
+type TransitionError struct {
+	Type conditionsv1.ConditionType
+
+	// From is the previous status, or empty when the condition was added.
+	From   corev1.ConditionStatus
+	To     corev1.ConditionStatus
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	var msg string
+	if e.From == "" {
+		msg = fmt.Sprintf("condition %s is not allowed to be added with status %s", e.Type, e.To)
+	} else {
+		msg = fmt.Sprintf("condition %s is not allowed to transition from %s to %s", e.Type, e.From, e.To)
+	}
+	if e.Reason != "" {
+		msg += fmt.Sprintf(" with reason %s", e.Reason)
+	}
+	return msg
+}
+
+// Check returns a *TransitionError when updating previous, nil when added, to
+// current is not allowed.
+func (m Machines) Check(previous *conditionsv1.Condition, current conditionsv1.Condition) error {
+	machine, ok := m[current.Type]
+	if !ok {
+		return nil
+	}
+	if previous == nil {
+		if len(machine.Initial) == 0 || conditionsv1.ContainsStatus(machine.Initial, current.Status) {
+			return nil
+		}
+		return &TransitionError{Type: current.Type, To: current.Status}
+	}
+	if previous.Status == current.Status {
+		return nil
+	}
+
+	reasonMatters := false
+	for _, transition := range machine.Transitions {
+		if transition.From != previous.Status || transition.To != current.Status {
+			continue
+		}
+		if len(transition.Reasons) == 0 || containsString(transition.Reasons, current.Reason) {
+			return nil
+		}
+		reasonMatters = true
+	}
+	err := &TransitionError{Type: current.Type, From: previous.Status, To: current.Status}
+	if reasonMatters {
+		err.Reason = current.Reason
+	}
+	return err
+}
+
+// SetStatusCondition behaves like conditionsv1.SetStatusCondition, unless the
+// transition to newCondition is not allowed: conditions are then left as they
+// are and a *TransitionError is returned.
+func (m Machines) SetStatusCondition(conditions *[]conditionsv1.Condition, newCondition conditionsv1.Condition) (bool, error) {
+	if conditions == nil {
+		conditions = &[]conditionsv1.Condition{}
+	}
+	if err := m.Check(conditionsv1.FindStatusCondition(*conditions, newCondition.Type), newCondition); err != nil {
+		return false, err
+	}
+	return conditionsv1.SetStatusCondition(conditions, newCondition), nil
+}
+
+// Reporter returns a conditionsv1.TransitionHandler calling report with the
+// *TransitionError of every disallowed transition, to report them without
+// rejecting them when used with the notifying setters.
+func (m Machines) Reporter(report func(object runtime.Object, err error)) conditionsv1.TransitionHandler {
+	return conditionsv1.TransitionHandlerFunc(func(object runtime.Object, transition conditionsv1.Transition) {
+		if err := m.Check(transition.Previous, transition.Current); err != nil {
+			report(object, err)
+		}
+	})
+}
+
+// ValidateConditions validates that the conditions, as added to a new object,
+// have allowed initial statuses. Errors are reported on the "conditions" path.
+func (m Machines) ValidateConditions(conditions []conditionsv1.Condition) field.ErrorList {
+	return m.ValidateConditionsUpdate(nil, conditions)
+}
+
+// ValidateConditionsUpdate validates that the update of the conditions old to
+// new only goes through allowed transitions. Errors are reported on the
+// "conditions" path.
+func (m Machines) ValidateConditionsUpdate(old, new []conditionsv1.Condition) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, condition := range new {
+		if err := m.Check(conditionsv1.FindStatusCondition(old, condition.Type), condition); err != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("conditions").Index(i).Child("status"), err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package statemachine
+
+import (
+	"errors"
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var machines = Machines{
+	conditionsv1.ConditionUpgradeable: {
+		Initial: []corev1.ConditionStatus{corev1.ConditionUnknown, corev1.ConditionFalse},
+		Transitions: []Transition{
+			{From: corev1.ConditionUnknown, To: corev1.ConditionFalse},
+			{From: corev1.ConditionUnknown, To: corev1.ConditionTrue},
+			{From: corev1.ConditionTrue, To: corev1.ConditionFalse},
+			{From: corev1.ConditionFalse, To: corev1.ConditionTrue, Reasons: []string{"PreconditionCleared"}},
+		},
+	},
+}
+
+func upgradeable(status corev1.ConditionStatus, reason string) conditionsv1.Condition {
+	return conditionsv1.Condition{Type: conditionsv1.ConditionUpgradeable, Status: status, Reason: reason}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name          string
+		previous      *conditionsv1.Condition
+		current       conditionsv1.Condition
+		expectedError string
+	}{
+		{
+			name:    "allowed initial status",
+			current: upgradeable(corev1.ConditionUnknown, ""),
+		},
+		{
+			name:          "disallowed initial status",
+			current:       upgradeable(corev1.ConditionTrue, "AsExpected"),
+			expectedError: "condition Upgradeable is not allowed to be added with status True",
+		},
+		{
+			name:     "allowed transition",
+			previous: &conditionsv1.Condition{Type: conditionsv1.ConditionUpgradeable, Status: corev1.ConditionTrue},
+			current:  upgradeable(corev1.ConditionFalse, "UpgradeInProgress"),
+		},
+		{
+			name:     "allowed transition with reason",
+			previous: &conditionsv1.Condition{Type: conditionsv1.ConditionUpgradeable, Status: corev1.ConditionFalse},
+			current:  upgradeable(corev1.ConditionTrue, "PreconditionCleared"),
+		},
+		{
+			name:          "disallowed reason",
+			previous:      &conditionsv1.Condition{Type: conditionsv1.ConditionUpgradeable, Status: corev1.ConditionFalse},
+			current:       upgradeable(corev1.ConditionTrue, "AsExpected"),
+			expectedError: "condition Upgradeable is not allowed to transition from False to True with reason AsExpected",
+		},
+		{
+			name:          "disallowed transition",
+			previous:      &conditionsv1.Condition{Type: conditionsv1.ConditionUpgradeable, Status: corev1.ConditionTrue},
+			current:       upgradeable(corev1.ConditionUnknown, ""),
+			expectedError: "condition Upgradeable is not allowed to transition from True to Unknown",
+		},
+		{
+			name:     "same status",
+			previous: &conditionsv1.Condition{Type: conditionsv1.ConditionUpgradeable, Status: corev1.ConditionFalse, Reason: "UpgradeInProgress"},
+			current:  upgradeable(corev1.ConditionFalse, "Blocked"),
+		},
+		{
+			name:     "unrestricted type",
+			previous: &conditionsv1.Condition{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue},
+			current:  conditionsv1.Condition{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionUnknown},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := machines.Check(tc.previous, tc.current)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("Error occurred unexpectedly: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedError {
+				t.Fatalf("Unexpected error '%v', expected '%v'", err, tc.expectedError)
+			}
+			var transitionErr *TransitionError
+			if !errors.As(err, &transitionErr) || transitionErr.Type != conditionsv1.ConditionUpgradeable {
+				t.Errorf("Unexpected error type %T", err)
+			}
+		})
+	}
+}
+
+func TestSetStatusCondition(t *testing.T) {
+	conditions := []conditionsv1.Condition{}
+	if _, err := machines.SetStatusCondition(&conditions, upgradeable(corev1.ConditionFalse, "UpgradeInProgress")); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+
+	changed, err := machines.SetStatusCondition(&conditions, upgradeable(corev1.ConditionTrue, "AsExpected"))
+	if err == nil || changed {
+		t.Errorf("Expected the transition to be rejected, changed: %t, err: %v", changed, err)
+	}
+	if !conditionsv1.IsStatusConditionFalse(conditions, conditionsv1.ConditionUpgradeable) {
+		t.Errorf("Unexpected conditions '%v'", conditions)
+	}
+
+	changed, err = machines.SetStatusCondition(&conditions, upgradeable(corev1.ConditionTrue, "PreconditionCleared"))
+	if err != nil || !changed {
+		t.Errorf("Expected the transition to be allowed, changed: %t, err: %v", changed, err)
+	}
+	if !conditionsv1.IsStatusConditionTrue(conditions, conditionsv1.ConditionUpgradeable) {
+		t.Errorf("Unexpected conditions '%v'", conditions)
+	}
+}
+
+func TestReporter(t *testing.T) {
+	reported := []error{}
+	reporter := machines.Reporter(func(object runtime.Object, err error) {
+		reported = append(reported, err)
+	})
+
+	conditions := []conditionsv1.Condition{}
+	conditionsv1.SetStatusConditionAndNotify(nil, &conditions, upgradeable(corev1.ConditionFalse, "UpgradeInProgress"), reporter)
+	conditionsv1.SetStatusConditionAndNotify(nil, &conditions, upgradeable(corev1.ConditionTrue, "AsExpected"), reporter)
+
+	if len(reported) != 1 || reported[0].Error() != "condition Upgradeable is not allowed to transition from False to True with reason AsExpected" {
+		t.Errorf("Unexpected reported errors '%v'", reported)
+	}
+	// Reported transitions are not rejected.
+	if !conditionsv1.IsStatusConditionTrue(conditions, conditionsv1.ConditionUpgradeable) {
+		t.Errorf("Unexpected conditions '%v'", conditions)
+	}
+}
+
+func TestValidateConditionsUpdate(t *testing.T) {
+	old := []conditionsv1.Condition{upgradeable(corev1.ConditionFalse, "UpgradeInProgress")}
+	new := []conditionsv1.Condition{
+		{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue},
+		upgradeable(corev1.ConditionTrue, "AsExpected"),
+	}
+
+	errs := machines.ValidateConditionsUpdate(old, new)
+	expected := "conditions[1].status: Forbidden: condition Upgradeable is not allowed to transition from False to True with reason AsExpected"
+	if len(errs) != 1 || errs[0].Error() != expected {
+		t.Errorf("Unexpected errors '%v', expected '%v'", errs, expected)
+	}
+
+	errs = machines.ValidateConditions([]conditionsv1.Condition{upgradeable(corev1.ConditionTrue, "")})
+	expected = "conditions[0].status: Forbidden: condition Upgradeable is not allowed to be added with status True"
+	if len(errs) != 1 || errs[0].Error() != expected {
+		t.Errorf("Unexpected errors '%v', expected '%v'", errs, expected)
+	}
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Transition describes a single update of a condition made by one of the
+// notifying setters.
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+// +protobuf=false
+type Transition struct {
+	// Previous is the condition as it was before the update, or nil when the
+	// condition was added by the update.
+	Previous *Condition
+
+	// Current is the condition as it is after the update.
+	Current Condition
+}
+
+// Added returns true when the condition did not exist before the update.
+func (t Transition) Added() bool {
+	return t.Previous == nil
+}
+
+// StatusChanged returns true when the condition was added or its status changed.
+func (t Transition) StatusChanged() bool {
+	return t.Previous == nil || t.Previous.Status != t.Current.Status
+}
+
+// ReasonChanged returns true when the condition was added or its reason changed.
+func (t Transition) ReasonChanged() bool {
+	return t.Previous == nil || t.Previous.Reason != t.Current.Reason
+}
+
+// MessageChanged returns true when the condition was added or its message changed.
+func (t Transition) MessageChanged() bool {
+	return t.Previous == nil || t.Previous.Message != t.Current.Message
+}
+
+// Changed returns true when the update changed anything *other than* LastHeartbeatTime.
+// It matches the value returned by SetStatusCondition.
+func (t Transition) Changed() bool {
+	return t.StatusChanged() || t.ReasonChanged() || t.MessageChanged()
+}
+
+// MessageOnly returns true when the message is the only field that changed.
+func (t Transition) MessageOnly() bool {
+	return t.MessageChanged() && !t.StatusChanged() && !t.ReasonChanged()
+}
+
+// TransitionHandler is notified of every update made by the notifying setters,
+// including heartbeat-only updates for which Transition.Changed returns false.
+type TransitionHandler interface {
+	OnTransition(object runtime.Object, transition Transition)
+}
+
+// TransitionHandlerFunc adapts an ordinary function to a TransitionHandler.
+type TransitionHandlerFunc func(object runtime.Object, transition Transition)
+
+// OnTransition calls f(object, transition).
+func (f TransitionHandlerFunc) OnTransition(object runtime.Object, transition Transition) {
+	f(object, transition)
+}
+
+// SetStatusConditionAndNotify behaves like SetStatusCondition and reports the
+// resulting Transition of the condition owned by object to each handler.
+func SetStatusConditionAndNotify(object runtime.Object, conditions *[]Condition, newCondition Condition, handlers ...TransitionHandler) bool {
+	return setAndNotify(SetStatusCondition, object, conditions, newCondition, handlers)
+}
+
+// SetStatusConditionNoHeartbeatAndNotify behaves like SetStatusConditionNoHeartbeat
+// and reports the resulting Transition of the condition owned by object to each handler.
+func SetStatusConditionNoHeartbeatAndNotify(object runtime.Object, conditions *[]Condition, newCondition Condition, handlers ...TransitionHandler) bool {
+	return setAndNotify(SetStatusConditionNoHeartbeat, object, conditions, newCondition, handlers)
+}
+
+func setAndNotify(set func(*[]Condition, Condition) bool, object runtime.Object, conditions *[]Condition, newCondition Condition, handlers []TransitionHandler) bool {
+	if conditions == nil {
+		conditions = &[]Condition{}
+	}
+	transition := Transition{}
+	if existingCondition := FindStatusCondition(*conditions, newCondition.Type); existingCondition != nil {
+		transition.Previous = existingCondition.DeepCopy()
+	}
+
+	changed := set(conditions, newCondition)
+	transition.Current = *FindStatusCondition(*conditions, newCondition.Type)
+	for _, handler := range handlers {
+		handler.OnTransition(object, transition)
+	}
+	return changed
+}
@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetStatusConditionAndNotify(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		testCondition         Condition
+		startConditions       []Condition
+		expectAdded           bool
+		expectChanged         bool
+		expectStatusChanged   bool
+		expectMessageOnly     bool
+		expectPreviousMessage string
+	}{
+		{
+			name: "add when empty",
+			testCondition: Condition{
+				Type:    ConditionAvailable,
+				Status:  corev1.ConditionTrue,
+				Reason:  "Testing",
+				Message: "Basic message",
+			},
+			startConditions:     []Condition{},
+			expectAdded:         true,
+			expectChanged:       true,
+			expectStatusChanged: true,
+		},
+		{
+			name: "status change",
+			testCondition: Condition{
+				Type:    ConditionDegraded,
+				Status:  corev1.ConditionTrue,
+				Reason:  "TestingDegradedTrue",
+				Message: "Degraded condition true",
+			},
+			startConditions: []Condition{
+				{
+					Type:    ConditionDegraded,
+					Status:  corev1.ConditionFalse,
+					Reason:  "TestingDegradedFalse",
+					Message: "Degraded condition false",
+				},
+			},
+			expectChanged:         true,
+			expectStatusChanged:   true,
+			expectPreviousMessage: "Degraded condition false",
+		},
+		{
+			name: "message only",
+			testCondition: Condition{
+				Type:    ConditionDegraded,
+				Status:  corev1.ConditionFalse,
+				Reason:  "TestingDegradedFalse",
+				Message: "Degraded condition still false",
+			},
+			startConditions: []Condition{
+				{
+					Type:    ConditionDegraded,
+					Status:  corev1.ConditionFalse,
+					Reason:  "TestingDegradedFalse",
+					Message: "Degraded condition false",
+				},
+			},
+			expectChanged:         true,
+			expectMessageOnly:     true,
+			expectPreviousMessage: "Degraded condition false",
+		},
+		{
+			name: "heartbeat only",
+			testCondition: Condition{
+				Type:    ConditionDegraded,
+				Status:  corev1.ConditionFalse,
+				Reason:  "TestingDegradedFalse",
+				Message: "Degraded condition false",
+			},
+			startConditions: []Condition{
+				{
+					Type:    ConditionDegraded,
+					Status:  corev1.ConditionFalse,
+					Reason:  "TestingDegradedFalse",
+					Message: "Degraded condition false",
+				},
+			},
+			expectPreviousMessage: "Degraded condition false",
+		},
+	}
+
+	object := &corev1.ConfigMap{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var transitions []Transition
+			handler := TransitionHandlerFunc(func(o runtime.Object, transition Transition) {
+				if o != object {
+					t.Errorf("Unexpected object '%v', expected '%v'", o, object)
+				}
+				transitions = append(transitions, transition)
+			})
+
+			conditions := make([]Condition, len(tc.startConditions))
+			copy(conditions, tc.startConditions)
+			changed := SetStatusConditionAndNotify(object, &conditions, tc.testCondition, handler)
+			if changed != tc.expectChanged {
+				t.Errorf("Unexpected return from SetStatusConditionAndNotify: expected: %t; actual: %t", tc.expectChanged, changed)
+			}
+			if len(transitions) != 1 {
+				t.Fatalf("Unexpected number of transitions: expected: 1; actual: %d", len(transitions))
+			}
+
+			transition := transitions[0]
+			if transition.Added() != tc.expectAdded {
+				t.Errorf("Unexpected Added(): expected: %t; actual: %t", tc.expectAdded, transition.Added())
+			}
+			if transition.Changed() != changed {
+				t.Errorf("Unexpected Changed(): expected: %t; actual: %t", changed, transition.Changed())
+			}
+			if transition.StatusChanged() != tc.expectStatusChanged {
+				t.Errorf("Unexpected StatusChanged(): expected: %t; actual: %t", tc.expectStatusChanged, transition.StatusChanged())
+			}
+			if transition.MessageOnly() != tc.expectMessageOnly {
+				t.Errorf("Unexpected MessageOnly(): expected: %t; actual: %t", tc.expectMessageOnly, transition.MessageOnly())
+			}
+			if transition.Previous != nil && transition.Previous.Message != tc.expectPreviousMessage {
+				t.Errorf("Unexpected previous message '%v', expected '%v'", transition.Previous.Message, tc.expectPreviousMessage)
+			}
+			compareConditionNoHeartbeat(t, &transition.Current, tc.testCondition)
+			if transition.Current.LastHeartbeatTime.IsZero() {
+				t.Error("lastHeartbeatTime should never be zero")
+			}
+		})
+	}
+}
+
+func TestPolarity(t *testing.T) {
+	testCases := []struct {
+		conditionType  ConditionType
+		status         corev1.ConditionStatus
+		expectAbnormal bool
+	}{
+		{conditionType: ConditionAvailable, status: corev1.ConditionTrue, expectAbnormal: false},
+		{conditionType: ConditionAvailable, status: corev1.ConditionFalse, expectAbnormal: true},
+		{conditionType: ConditionDegraded, status: corev1.ConditionTrue, expectAbnormal: true},
+		{conditionType: ConditionDegraded, status: corev1.ConditionFalse, expectAbnormal: false},
+		{conditionType: ConditionProgressing, status: corev1.ConditionFalse, expectAbnormal: false},
+		{conditionType: ConditionUpgradeable, status: corev1.ConditionUnknown, expectAbnormal: false},
+		{conditionType: "Reconciling", status: corev1.ConditionTrue, expectAbnormal: true},
+		{conditionType: "Stalled", status: corev1.ConditionTrue, expectAbnormal: true},
+		{conditionType: "Stalled", status: corev1.ConditionFalse, expectAbnormal: false},
+		{conditionType: "Custom", status: corev1.ConditionFalse, expectAbnormal: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.conditionType)+string(tc.status), func(t *testing.T) {
+			abnormal := PolarityOf(tc.conditionType).IsAbnormal(tc.status)
+			if abnormal != tc.expectAbnormal {
+				t.Errorf("Unexpected IsAbnormal: expected: %t; actual: %t", tc.expectAbnormal, abnormal)
+			}
+		})
+	}
+}
@@ -9,24 +9,39 @@ import (
 // reconciliation functionality.
 // +k8s:deepcopy-gen=true
 type Condition struct {
-	Type ConditionType `json:"type" description:"type of condition ie. Available|Progressing|Degraded."`
+	// type of condition ie. Available|Progressing|Degraded.
+	Type ConditionType `json:"type" description:"type of condition ie. Available|Progressing|Degraded." protobuf:"bytes,1,opt,name=type,casttype=ConditionType"`
 
-	Status corev1.ConditionStatus `json:"status" description:"status of the condition, one of True, False, Unknown"`
+	// status of the condition, one of True, False, Unknown.
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status corev1.ConditionStatus `json:"status" description:"status of the condition, one of True, False, Unknown" protobuf:"bytes,2,opt,name=status,casttype=k8s.io/api/core/v1.ConditionStatus"`
 
+	// one-word CamelCase reason for the condition's last transition.
 	// +optional
-	Reason string `json:"reason,omitempty" description:"one-word CamelCase reason for the condition's last transition"`
+	// +kubebuilder:validation:Pattern=`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`
+	// +kubebuilder:validation:MaxLength=1024
+	Reason string `json:"reason,omitempty" description:"one-word CamelCase reason for the condition's last transition" protobuf:"bytes,3,opt,name=reason"`
 
+	// human-readable message indicating details about last transition.
 	// +optional
-	Message string `json:"message,omitempty" description:"human-readable message indicating details about last transition"`
+	// +kubebuilder:validation:MaxLength=32768
+	Message string `json:"message,omitempty" description:"human-readable message indicating details about last transition" protobuf:"bytes,4,opt,name=message"`
 
+	// last time we got an update on a given condition.
 	// +optional
-	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime" description:"last time we got an update on a given condition"`
+	// +nullable
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime" description:"last time we got an update on a given condition" protobuf:"bytes,5,opt,name=lastHeartbeatTime"`
 
+	// last time the condition transit from one status to another.
 	// +optional
-	LastTransitionTime metav1.Time `json:"lastTransitionTime" description:"last time the condition transit from one status to another"`
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime" description:"last time the condition transit from one status to another" protobuf:"bytes,6,opt,name=lastTransitionTime"`
 }
 
 // ConditionType is the state of the operator's reconciliation functionality.
+// It is CamelCase, optionally prefixed with a DNS subdomain as in example.com/Ready.
+// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
+// +kubebuilder:validation:MaxLength=316
 type ConditionType string
 
 const (
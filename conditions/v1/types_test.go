@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"go/types"
+	"runtime"
+	"testing"
+
+	conditionsschema "github.com/openshift/custom-resource-status/conditions/v1/schema"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-tools/pkg/crd"
+	crdmarkers "sigs.k8s.io/controller-tools/pkg/crd/markers"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+// conditionSchema generates the schema of Condition with the controller-tools
+// parser controller-gen uses, from the Go types and markers of this package.
+func conditionSchema(t *testing.T) apiextensionsv1.JSONSchemaProps {
+	t.Helper()
+	pkgs, err := loader.LoadRoots(".")
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	pkg := pkgs[0]
+	setTypesSizes(pkg, types.SizesFor("gc", runtime.GOARCH), map[*loader.Package]bool{})
+	registry := &markers.Registry{}
+	if err := crdmarkers.Register(registry); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	// The type checker of controller-gen only checks the imports referenced
+	// by type declarations, so the imports of this package are checked first
+	// for it to type-check without errors.
+	checker := &loader.TypeChecker{NodeFilters: []loader.NodeFilter{crd.Generator{}.CheckFilter()}}
+	for _, imp := range pkg.Imports() {
+		checker.Check(imp)
+	}
+	parser := &crd.Parser{
+		Collector: &markers.Collector{Registry: registry},
+		Checker:   checker,
+	}
+	crd.AddKnownTypes(parser)
+
+	parser.NeedPackage(pkg)
+	ident := crd.TypeIdent{Package: pkg, Name: "Condition"}
+	parser.NeedFlattenedSchemaFor(ident)
+	for _, pkgErr := range pkg.Errors {
+		t.Fatalf("Error occurred unexpectedly: %v", pkgErr)
+	}
+	return parser.FlattenedSchemata[ident]
+}
+
+// setTypesSizes sets the sizes of pkg and of its imports, which go/packages
+// leaves unset on Go releases it does not know the sizes of.
+func setTypesSizes(pkg *loader.Package, sizes types.Sizes, visited map[*loader.Package]bool) {
+	if visited[pkg] {
+		return
+	}
+	visited[pkg] = true
+	pkg.TypesSizes = sizes
+	for _, imp := range pkg.Imports() {
+		setTypesSizes(imp, sizes, visited)
+	}
+}
+
+func TestConditionValidationMarkers(t *testing.T) {
+	schema := conditionSchema(t)
+
+	if len(schema.Required) != 2 || schema.Required[0] != "type" || schema.Required[1] != "status" {
+		t.Errorf("Unexpected required fields '%v', expected '[type status]'", schema.Required)
+	}
+
+	conditionType := schema.Properties["type"]
+	if conditionType.Pattern == "" || conditionType.MaxLength == nil || *conditionType.MaxLength != 316 {
+		t.Errorf("Unexpected type schema '%+v'", conditionType)
+	}
+
+	status := schema.Properties["status"]
+	enum := []string{}
+	for _, value := range status.Enum {
+		enum = append(enum, string(value.Raw))
+	}
+	if len(enum) != 3 || enum[0] != `"True"` || enum[1] != `"False"` || enum[2] != `"Unknown"` {
+		t.Errorf("Unexpected status enum '%v', expected '[\"True\" \"False\" \"Unknown\"]'", enum)
+	}
+
+	reason := schema.Properties["reason"]
+	if expected := `^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`; reason.Pattern != expected {
+		t.Errorf("Unexpected reason pattern '%v', expected '%v'", reason.Pattern, expected)
+	}
+	if reason.MaxLength == nil || *reason.MaxLength != 1024 {
+		t.Errorf("Unexpected reason maxLength '%v', expected '1024'", reason.MaxLength)
+	}
+
+	message := schema.Properties["message"]
+	if message.MaxLength == nil || *message.MaxLength != 32768 {
+		t.Errorf("Unexpected message maxLength '%v', expected '32768'", message.MaxLength)
+	}
+
+	for _, name := range []string{"lastHeartbeatTime", "lastTransitionTime"} {
+		timestamp := schema.Properties[name]
+		if timestamp.Type != "string" || timestamp.Format != "date-time" || !timestamp.Nullable {
+			t.Errorf("Unexpected %s schema '%+v'", name, timestamp)
+		}
+	}
+}
+
+// TestConditionSchema checks that the hand-written schema of the schema
+// package is the schema controller-gen generates from Condition.
+func TestConditionSchema(t *testing.T) {
+	generated := conditionSchema(t)
+	if condition := conditionsschema.Condition(); !equality.Semantic.DeepEqual(condition, generated) {
+		t.Errorf("Unexpected schema '%+v', expected '%+v'", condition, generated)
+	}
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateConditionsUpdate validates the update of the conditions old to new,
+// enforcing the semantics of SetStatusCondition: LastTransitionTime changes when,
+// and only when, the status of a condition changes, and never moves backwards.
+// Timestamps are compared with the second precision they are serialized with.
+//
+// SetStatusCondition can transition a condition again within the second of its
+// previous transition, which cannot be told apart from an unchanged
+// LastTransitionTime once both are serialized. A status change with an equal
+// second-precision LastTransitionTime is therefore accepted, unless the
+// timestamps still carry their sub-second part and are equal, as conditions
+// updated in memory do.
+// Errors are reported on the "conditions" path.
+func ValidateConditionsUpdate(old, new []Condition) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i := range new {
+		newCondition := new[i]
+		oldCondition := FindStatusCondition(old, newCondition.Type)
+		if oldCondition == nil {
+			continue
+		}
+
+		fldPath := field.NewPath("conditions").Index(i).Child("lastTransitionTime")
+		oldTime, newTime := oldCondition.LastTransitionTime.Rfc3339Copy(), newCondition.LastTransitionTime.Rfc3339Copy()
+		statusChanged := oldCondition.Status != newCondition.Status
+		timeChanged := !oldTime.Equal(&newTime)
+		switch {
+		case newTime.Before(&oldTime):
+			allErrs = append(allErrs, field.Invalid(fldPath, formatTime(newTime), fmt.Sprintf("must not move backwards from %s", formatTime(oldTime))))
+		case statusChanged && oldCondition.LastTransitionTime.Equal(&newCondition.LastTransitionTime) && !oldTime.Equal(&oldCondition.LastTransitionTime):
+			allErrs = append(allErrs, field.Invalid(fldPath, formatTime(newTime), fmt.Sprintf("must change when the status changes from %s to %s", oldCondition.Status, newCondition.Status)))
+		case !statusChanged && timeChanged:
+			allErrs = append(allErrs, field.Invalid(fldPath, formatTime(newTime), fmt.Sprintf("must not change while the status remains %s", newCondition.Status)))
+		}
+	}
+	return allErrs
+}
+
+func formatTime(t metav1.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateConditionsUpdate(t *testing.T) {
+	before := metav1.NewTime(time.Date(2021, 1, 1, 11, 0, 0, 0, time.UTC))
+	after := metav1.NewTime(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC))
+	afterWithNanoseconds := metav1.NewTime(time.Date(2021, 1, 1, 12, 0, 0, 500, time.UTC))
+
+	condition := func(conditionType ConditionType, status corev1.ConditionStatus, lastTransitionTime metav1.Time) Condition {
+		return Condition{Type: conditionType, Status: status, LastTransitionTime: lastTransitionTime}
+	}
+
+	testCases := []struct {
+		name           string
+		old            []Condition
+		new            []Condition
+		expectedErrors []string
+	}{
+		{
+			name: "status change with transition",
+			old:  []Condition{condition(ConditionAvailable, corev1.ConditionFalse, before)},
+			new:  []Condition{condition(ConditionAvailable, corev1.ConditionTrue, after)},
+		},
+		{
+			name: "status change within the second",
+			old:  []Condition{condition(ConditionAvailable, corev1.ConditionFalse, after)},
+			new:  []Condition{condition(ConditionAvailable, corev1.ConditionTrue, afterWithNanoseconds)},
+		},
+		{
+			name: "status change within the second, as serialized",
+			old:  []Condition{condition(ConditionAvailable, corev1.ConditionFalse, after)},
+			new:  []Condition{condition(ConditionAvailable, corev1.ConditionTrue, after)},
+		},
+		{
+			name: "heartbeat only",
+			old:  []Condition{condition(ConditionAvailable, corev1.ConditionTrue, after)},
+			new:  []Condition{condition(ConditionAvailable, corev1.ConditionTrue, afterWithNanoseconds)},
+		},
+		{
+			name: "added and removed conditions",
+			old:  []Condition{condition(ConditionDegraded, corev1.ConditionTrue, after)},
+			new:  []Condition{condition(ConditionAvailable, corev1.ConditionTrue, before)},
+		},
+		{
+			name: "transition moving backwards",
+			old:  []Condition{condition(ConditionAvailable, corev1.ConditionFalse, after)},
+			new:  []Condition{condition(ConditionAvailable, corev1.ConditionTrue, before)},
+			expectedErrors: []string{
+				`conditions[0].lastTransitionTime: Invalid value: "2021-01-01T11:00:00Z": must not move backwards from 2021-01-01T12:00:00Z`,
+			},
+		},
+		{
+			name: "status change without transition",
+			old:  []Condition{condition(ConditionAvailable, corev1.ConditionFalse, afterWithNanoseconds)},
+			new:  []Condition{condition(ConditionAvailable, corev1.ConditionTrue, afterWithNanoseconds)},
+			expectedErrors: []string{
+				`conditions[0].lastTransitionTime: Invalid value: "2021-01-01T12:00:00Z": must change when the status changes from False to True`,
+			},
+		},
+		{
+			name: "transition without status change",
+			old: []Condition{
+				condition(ConditionAvailable, corev1.ConditionTrue, before),
+				condition(ConditionDegraded, corev1.ConditionFalse, before),
+			},
+			new: []Condition{
+				condition(ConditionAvailable, corev1.ConditionTrue, before),
+				condition(ConditionDegraded, corev1.ConditionFalse, after),
+			},
+			expectedErrors: []string{
+				`conditions[1].lastTransitionTime: Invalid value: "2021-01-01T12:00:00Z": must not change while the status remains False`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateConditionsUpdate(tc.old, tc.new)
+			if len(errs) != len(tc.expectedErrors) {
+				t.Fatalf("Unexpected errors '%v', expected '%v'", errs, tc.expectedErrors)
+			}
+			for i, err := range errs {
+				if err.Error() != tc.expectedErrors[i] {
+					t.Errorf("Unexpected error '%v', expected '%v'", err, tc.expectedErrors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConditionsUpdateAfterSetStatusCondition(t *testing.T) {
+	old := []Condition{}
+	SetStatusCondition(&old, Condition{Type: ConditionAvailable, Status: corev1.ConditionFalse})
+	SetStatusCondition(&old, Condition{Type: ConditionDegraded, Status: corev1.ConditionFalse})
+
+	// Transition Available again within the second of its previous transition.
+	new := []Condition{}
+	for _, condition := range old {
+		new = append(new, *condition.DeepCopy())
+	}
+	SetStatusCondition(&new, Condition{Type: ConditionAvailable, Status: corev1.ConditionTrue})
+	SetStatusCondition(&new, Condition{Type: ConditionDegraded, Status: corev1.ConditionFalse, Message: "Still not degraded"})
+
+	// Validate the conditions in memory, and as decoded by a webhook or a
+	// registry strategy, with both sides truncated to seconds.
+	serialized := func(conditions []Condition) []Condition {
+		truncated := []Condition{}
+		for _, condition := range conditions {
+			condition.LastTransitionTime = condition.LastTransitionTime.Rfc3339Copy()
+			condition.LastHeartbeatTime = condition.LastHeartbeatTime.Rfc3339Copy()
+			truncated = append(truncated, condition)
+		}
+		return truncated
+	}
+	for name, conditions := range map[string][2][]Condition{
+		"in memory":  {old, new},
+		"serialized": {serialized(old), serialized(new)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if errs := ValidateConditionsUpdate(conditions[0], conditions[1]); len(errs) != 0 {
+				t.Errorf("Unexpected errors '%v'", errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Package webhook POSTs a JSON payload to HTTP endpoints when conditions
+// managed through conditions/v1 transition, so chat-ops and incident tooling
+// learn about them without polling.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	objectreferencesv1 "github.com/openshift/custom-resource-status/objectreferences/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// SignatureHeader is the header holding the HMAC-SHA256 signature of the
+// payload, as "sha256=" followed by the hex-encoded signature, for endpoints
+// with a secret.
+const SignatureHeader = "X-Signature-256"
+
+// maxErrorBodyLength is the maximum length of the response body included in
+// the errors of failed deliveries.
+const maxErrorBodyLength = 256
+
+// DefaultBackoff is the backoff used when Options.Backoff is not set. It
+// makes up to 4 attempts, 0.5s, 1s and 2s apart.
+var DefaultBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    4,
+}
+
+// Payload is the JSON body POSTed for a transition.
+type Payload struct {
+	// Timestamp is the time the transition was notified.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Object is the object owning the condition.
+	Object corev1.ObjectReference `json:"object"`
+
+	Type conditionsv1.ConditionType `json:"type"`
+
+	// From is the status before the transition, empty when the condition was added.
+	From corev1.ConditionStatus `json:"from,omitempty"`
+
+	To corev1.ConditionStatus `json:"to"`
+
+	Reason string `json:"reason,omitempty"`
+
+	Message string `json:"message,omitempty"`
+}
+
+// Endpoint is an HTTP endpoint notified of transitions.
+type Endpoint struct {
+	// URL the payloads are POSTed to.
+	URL string
+
+	// Secret signs the payloads in the SignatureHeader when set.
+	Secret []byte
+
+	// Types restricts the notified transitions to these condition types.
+	// All types are notified when empty.
+	Types []conditionsv1.ConditionType
+
+	// Statuses restricts the notified transitions to those to these statuses.
+	// All statuses are notified when empty.
+	Statuses []corev1.ConditionStatus
+}
+
+// Matches returns true when the endpoint is notified of transitions of
+// conditionType to status.
+func (e Endpoint) Matches(conditionType conditionsv1.ConditionType, status corev1.ConditionStatus) bool {
+	return (len(e.Types) == 0 || containsType(e.Types, conditionType)) &&
+		(len(e.Statuses) == 0 || conditionsv1.ContainsStatus(e.Statuses, status))
+}
+
+// Options configures a Notifier.
+type Options struct {
+	// Endpoints are the endpoints notified of transitions.
+	Endpoints []Endpoint
+
+	// Client sends the requests. A client with a 10s timeout is used when nil.
+	Client *http.Client
+
+	// Backoff configures the retries of failed deliveries, Steps being the
+	// maximum number of attempts. DefaultBackoff is used when Steps is 0.
+	// Deliveries are retried on network errors, 429 and 5xx responses.
+	Backoff wait.Backoff
+
+	// OnError is called with the last error of deliveries that failed after
+	// all attempts. Failed deliveries are dropped when nil.
+	OnError func(endpoint Endpoint, payload Payload, err error)
+}
+
+// Notifier is a conditionsv1.TransitionHandler POSTing a Payload to the
+// matching endpoints for every transition that changed the status of the
+// condition. Deliveries run in the background so the setters do not block;
+// Wait blocks until they complete.
+type Notifier struct {
+	options Options
+	now     func() time.Time
+	pending sync.WaitGroup
+}
+
+var _ conditionsv1.TransitionHandler = &Notifier{}
+
+// NewNotifier returns a Notifier delivering to the endpoints of options.
+func NewNotifier(options Options) *Notifier {
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if options.Backoff.Steps == 0 {
+		options.Backoff = DefaultBackoff
+	}
+	return &Notifier{
+		options: options,
+		now:     time.Now,
+	}
+}
+
+// OnTransition delivers a Payload for transition to the matching endpoints
+// when the status of the condition changed.
+func (n *Notifier) OnTransition(object runtime.Object, transition conditionsv1.Transition) {
+	if !transition.StatusChanged() {
+		return
+	}
+
+	condition := transition.Current
+	payload := Payload{
+		Timestamp: n.now(),
+		Object:    objectreferencesv1.ObjectReferenceFor(object),
+		Type:      condition.Type,
+		To:        condition.Status,
+		Reason:    condition.Reason,
+		Message:   condition.Message,
+	}
+	if transition.Previous != nil {
+		payload.From = transition.Previous.Status
+	}
+	body, err := json.Marshal(payload)
+
+	for _, endpoint := range n.options.Endpoints {
+		if !endpoint.Matches(condition.Type, condition.Status) {
+			continue
+		}
+		if err != nil {
+			n.reportError(endpoint, payload, err)
+			continue
+		}
+		n.pending.Add(1)
+		go func(endpoint Endpoint) {
+			defer n.pending.Done()
+			if err := n.deliver(endpoint, body); err != nil {
+				n.reportError(endpoint, payload, err)
+			}
+		}(endpoint)
+	}
+}
+
+// Wait blocks until all the pending deliveries completed, successfully or not.
+func (n *Notifier) Wait() {
+	n.pending.Wait()
+}
+
+// deliver POSTs body to endpoint, retrying with the backoff of the notifier.
+func (n *Notifier) deliver(endpoint Endpoint, body []byte) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(n.options.Backoff, func() (bool, error) {
+		retry, err := n.post(endpoint, body)
+		lastErr = err
+		if err != nil && !retry {
+			return false, err
+		}
+		return err == nil, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("delivering to %s failed after %d attempts: %v", endpoint.URL, n.options.Backoff.Steps, lastErr)
+	}
+	return err
+}
+
+// post POSTs body to endpoint once, and returns whether a failure may be retried.
+func (n *Notifier) post(endpoint Endpoint, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(endpoint.Secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	}
+
+	resp, err := n.options.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		// Drain the body so the connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	if respBody = bytes.TrimSpace(respBody); len(respBody) > 0 {
+		return retry, fmt.Errorf("delivering to %s failed: %s: %s", endpoint.URL, resp.Status, respBody)
+	}
+	return retry, fmt.Errorf("delivering to %s failed: %s", endpoint.URL, resp.Status)
+}
+
+func (n *Notifier) reportError(endpoint Endpoint, payload Payload, err error) {
+	if n.options.OnError != nil {
+		n.options.OnError(endpoint, payload, err)
+	}
+}
+
+// Sign returns the value of the SignatureHeader for body signed with secret.
+// Receivers should compare it to the header with hmac.Equal.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func containsType(types []conditionsv1.ConditionType, conditionType conditionsv1.ConditionType) bool {
+	for _, t := range types {
+		if t == conditionType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,247 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var fastBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 3}
+
+// receiver records the requests of an httptest server, responding with the
+// statuses in turn and a 1MiB body, large enough for the connection to only be
+// reused when the client reads it, then with 200.
+type receiver struct {
+	lock     sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	if len(r.statuses) > 0 {
+		w.WriteHeader(r.statuses[0])
+		w.Write([]byte("rejected " + strings.Repeat("x", 1<<20)))
+		r.statuses = r.statuses[1:]
+	}
+}
+
+func (r *receiver) payloads(t *testing.T) []Payload {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	payloads := []Payload{}
+	for _, body := range r.bodies {
+		payload := Payload{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("Error occurred unexpectedly: %v", err)
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads
+}
+
+func TestNotifier(t *testing.T) {
+	all := &receiver{}
+	allServer := httptest.NewServer(all)
+	defer allServer.Close()
+	degraded := &receiver{}
+	degradedServer := httptest.NewServer(degraded)
+	defer degradedServer.Close()
+
+	notifier := NewNotifier(Options{
+		Endpoints: []Endpoint{
+			{URL: allServer.URL},
+			{
+				URL:      degradedServer.URL,
+				Secret:   []byte("secret"),
+				Types:    []conditionsv1.ConditionType{conditionsv1.ConditionDegraded},
+				Statuses: []corev1.ConditionStatus{corev1.ConditionTrue},
+			},
+		},
+		Backoff: fastBackoff,
+		OnError: func(endpoint Endpoint, payload Payload, err error) {
+			t.Errorf("Error occurred unexpectedly: %v", err)
+		},
+	})
+	timestamp := time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC)
+	notifier.now = func() time.Time { return timestamp }
+
+	object := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "foo", UID: "fooid"},
+	}
+	conditions := []conditionsv1.Condition{}
+	set := func(conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason string) {
+		conditionsv1.SetStatusConditionAndNotify(object, &conditions, conditionsv1.Condition{
+			Type:    conditionType,
+			Status:  status,
+			Reason:  reason,
+			Message: "Condition " + reason,
+		}, notifier)
+		notifier.Wait()
+	}
+
+	set(conditionsv1.ConditionAvailable, corev1.ConditionTrue, "AsExpected")
+	set(conditionsv1.ConditionDegraded, corev1.ConditionFalse, "AsExpected")
+	set(conditionsv1.ConditionDegraded, corev1.ConditionFalse, "StillAsExpected")
+	set(conditionsv1.ConditionDegraded, corev1.ConditionTrue, "Timeout")
+
+	expected := []Payload{
+		{Type: conditionsv1.ConditionAvailable, To: corev1.ConditionTrue, Reason: "AsExpected", Message: "Condition AsExpected"},
+		{Type: conditionsv1.ConditionDegraded, To: corev1.ConditionFalse, Reason: "AsExpected", Message: "Condition AsExpected"},
+		{Type: conditionsv1.ConditionDegraded, From: corev1.ConditionFalse, To: corev1.ConditionTrue, Reason: "Timeout", Message: "Condition Timeout"},
+	}
+	for i := range expected {
+		expected[i].Timestamp = timestamp
+		expected[i].Object = corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test-namespace", Name: "foo", UID: "fooid"}
+	}
+
+	payloads := all.payloads(t)
+	if len(payloads) != len(expected) {
+		t.Fatalf("Unexpected payloads '%v', expected '%v'", payloads, expected)
+	}
+	for i := range expected {
+		if !payloads[i].Timestamp.Equal(expected[i].Timestamp) {
+			t.Errorf("Unexpected timestamp '%v', expected '%v'", payloads[i].Timestamp, expected[i].Timestamp)
+		}
+		payloads[i].Timestamp = expected[i].Timestamp
+		if payloads[i] != expected[i] {
+			t.Errorf("Unexpected payload '%v', expected '%v'", payloads[i], expected[i])
+		}
+	}
+
+	degradedPayloads := degraded.payloads(t)
+	if len(degradedPayloads) != 1 || degradedPayloads[0].Reason != "Timeout" {
+		t.Fatalf("Unexpected payloads '%v', expected only Degraded=True", degradedPayloads)
+	}
+	request := degraded.requests[0]
+	if request.Method != http.MethodPost || request.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected request '%v %v', expected a JSON POST", request.Method, request.Header.Get("Content-Type"))
+	}
+	signature := request.Header.Get(SignatureHeader)
+	if !strings.HasPrefix(signature, "sha256=") || !hmac.Equal([]byte(signature), []byte(Sign([]byte("secret"), degraded.bodies[0]))) {
+		t.Errorf("Unexpected signature '%v', expected '%v'", signature, Sign([]byte("secret"), degraded.bodies[0]))
+	}
+	if signature := all.requests[0].Header.Get(SignatureHeader); signature != "" {
+		t.Errorf("Unexpected signature '%v', expected none without a secret", signature)
+	}
+}
+
+func TestNotifierRetries(t *testing.T) {
+	testCases := []struct {
+		name             string
+		statuses         []int
+		expectedRequests int
+		expectedError    string
+	}{
+		{
+			name:             "success",
+			statuses:         nil,
+			expectedRequests: 1,
+		},
+		{
+			name:             "retried",
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			expectedRequests: 3,
+		},
+		{
+			name:             "attempts exhausted",
+			statuses:         []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable},
+			expectedRequests: 3,
+			expectedError:    "failed after 3 attempts: delivering to",
+		},
+		{
+			name:             "not retried",
+			statuses:         []int{http.StatusBadRequest},
+			expectedRequests: 1,
+			expectedError:    "400 Bad Request: rejected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &receiver{statuses: tc.statuses}
+			server := httptest.NewUnstartedServer(r)
+			connections := 0
+			server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					r.lock.Lock()
+					connections++
+					r.lock.Unlock()
+				}
+			}
+			server.Start()
+			defer server.Close()
+
+			errs := []error{}
+			notifier := NewNotifier(Options{
+				Endpoints: []Endpoint{{URL: server.URL}},
+				Backoff:   fastBackoff,
+				OnError: func(endpoint Endpoint, payload Payload, err error) {
+					errs = append(errs, err)
+				},
+			})
+			notifier.OnTransition(&corev1.ConfigMap{}, conditionsv1.Transition{
+				Current: conditionsv1.Condition{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionTrue},
+			})
+			notifier.Wait()
+
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			if len(r.requests) != tc.expectedRequests {
+				t.Errorf("Unexpected requests '%v', expected '%v'", len(r.requests), tc.expectedRequests)
+			}
+			if connections != 1 {
+				t.Errorf("Unexpected connections '%v', expected the attempts to reuse '1'", connections)
+			}
+			switch {
+			case tc.expectedError == "" && len(errs) != 0:
+				t.Errorf("Error occurred unexpectedly: %v", errs)
+			case tc.expectedError != "" && (len(errs) != 1 || !strings.Contains(errs[0].Error(), tc.expectedError)):
+				t.Errorf("Unexpected errors '%v', expected '%v'", errs, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestNotifierNilObject(t *testing.T) {
+	r := &receiver{}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	notifier := NewNotifier(Options{Endpoints: []Endpoint{{URL: server.URL}}})
+	conditions := []conditionsv1.Condition{}
+	conditionsv1.SetStatusConditionAndNotify(nil, &conditions, conditionsv1.Condition{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue}, notifier)
+	notifier.Wait()
+
+	if payloads := r.payloads(t); len(payloads) != 1 || payloads[0].Object != (corev1.ObjectReference{}) {
+		t.Errorf("Unexpected payloads '%+v', expected one with an empty object", payloads)
+	}
+}
+
+func TestNewNotifierDefaults(t *testing.T) {
+	notifier := NewNotifier(Options{})
+	if notifier.options.Client == nil {
+		t.Errorf("Unexpected client '<nil>', expected a default client")
+	}
+	if notifier.options.Backoff != DefaultBackoff {
+		t.Errorf("Unexpected backoff '%v', expected '%v'", notifier.options.Backoff, DefaultBackoff)
+	}
+}
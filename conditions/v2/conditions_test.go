@@ -0,0 +1,147 @@
+package v2
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestSetStatusCondition(t *testing.T) {
+	conditions := []Condition{}
+	if !SetStatusCondition(&conditions, Condition{Type: ConditionAvailable, Status: corev1.ConditionTrue, Reason: "AsExpected"}) {
+		t.Error("Expected the added condition to be changed")
+	}
+	condition := FindStatusCondition(conditions, ConditionAvailable)
+	if condition == nil || condition.LastHeartbeatTime == nil || condition.LastTransitionTime == nil {
+		t.Fatalf("Unexpected condition '%v'", condition)
+	}
+	if !IsStatusConditionTrue(conditions, ConditionAvailable) {
+		t.Error("Expected the condition to be true")
+	}
+
+	if SetStatusCondition(&conditions, Condition{Type: ConditionAvailable, Status: corev1.ConditionTrue, Reason: "AsExpected"}) {
+		t.Error("Expected the condition not to be changed")
+	}
+	if !SetStatusCondition(&conditions, Condition{Type: ConditionAvailable, Status: corev1.ConditionFalse, Reason: "Deploying"}) {
+		t.Error("Expected the condition to be changed")
+	}
+	if !IsStatusConditionFalse(conditions, ConditionAvailable) {
+		t.Error("Expected the condition to be false")
+	}
+
+	RemoveStatusCondition(&conditions, ConditionAvailable)
+	if len(conditions) != 0 {
+		t.Errorf("Unexpected conditions '%v'", conditions)
+	}
+}
+
+func TestSetStatusConditionNoHeartbeat(t *testing.T) {
+	conditions := []Condition{}
+	SetStatusConditionNoHeartbeat(&conditions, Condition{Type: ConditionDegraded, Status: corev1.ConditionFalse, LastHeartbeatTime: &metav1.Time{Time: time.Now()}})
+
+	condition := FindStatusCondition(conditions, ConditionDegraded)
+	if condition.LastHeartbeatTime != nil || condition.LastTransitionTime == nil {
+		t.Errorf("Unexpected condition '%v'", condition)
+	}
+
+	data, err := json.Marshal(conditions)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	expected := `[{"type":"Degraded","status":"False","lastTransitionTime":"` + condition.LastTransitionTime.UTC().Format(time.RFC3339) + `"}]`
+	if string(data) != expected {
+		t.Errorf("Unexpected JSON '%s', expected '%s'", data, expected)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	set := []Condition{}
+	SetStatusCondition(&set, Condition{Type: ConditionAvailable, Status: corev1.ConditionTrue, Reason: "AsExpected", Message: "All components are available"})
+	SetStatusConditionNoHeartbeat(&set, Condition{Type: ConditionDegraded, Status: corev1.ConditionFalse})
+
+	testCases := []struct {
+		name       string
+		conditions []Condition
+	}{
+		{
+			name:       "set conditions",
+			conditions: set,
+		},
+		{
+			name: "unset timestamps",
+			conditions: []Condition{
+				{Type: ConditionProgressing, Status: corev1.ConditionUnknown},
+			},
+		},
+		{
+			name:       "empty",
+			conditions: []Condition{},
+		},
+	}
+
+	codecs := []struct {
+		name      string
+		marshal   func(interface{}) ([]byte, error)
+		unmarshal func([]byte, interface{}) error
+	}{
+		{name: "json", marshal: json.Marshal, unmarshal: json.Unmarshal},
+		{name: "yaml", marshal: yaml.Marshal, unmarshal: func(data []byte, v interface{}) error { return yaml.Unmarshal(data, v) }},
+	}
+
+	for _, tc := range testCases {
+		for _, codec := range codecs {
+			t.Run(tc.name+" "+codec.name, func(t *testing.T) {
+				data, err := codec.marshal(tc.conditions)
+				if err != nil {
+					t.Fatalf("Error occurred unexpectedly: %v", err)
+				}
+				decoded := []Condition{}
+				if err := codec.unmarshal(data, &decoded); err != nil {
+					t.Fatalf("Error occurred unexpectedly: %v", err)
+				}
+				if !equality.Semantic.DeepEqual(decoded, tc.conditions) {
+					t.Errorf("Unexpected decoded conditions '%v', expected '%v'\n%s", decoded, tc.conditions, data)
+				}
+
+				// Setting the same conditions on the decoded copy is not a change.
+				for _, condition := range tc.conditions {
+					if SetStatusCondition(&decoded, condition) {
+						t.Errorf("Unexpected change setting '%v'", condition)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestTimestampsTruncated(t *testing.T) {
+	conditions := []Condition{}
+	SetStatusCondition(&conditions, Condition{Type: ConditionAvailable, Status: corev1.ConditionTrue})
+	SetStatusCondition(&conditions, Condition{Type: ConditionAvailable, Status: corev1.ConditionFalse})
+
+	condition := conditions[0]
+	for _, timestamp := range []*metav1.Time{condition.LastHeartbeatTime, condition.LastTransitionTime} {
+		if timestamp.Nanosecond() != 0 {
+			t.Errorf("Unexpected timestamp '%v' with sub-second precision", timestamp)
+		}
+	}
+
+	// The decoded condition must be equal to the one in memory, so that
+	// comparing them does not report a change of the timestamps.
+	data, err := json.Marshal(condition)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	decoded := Condition{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	if !decoded.LastTransitionTime.Equal(condition.LastTransitionTime) || !decoded.LastHeartbeatTime.Equal(condition.LastHeartbeatTime) {
+		t.Errorf("Unexpected decoded condition '%v', expected '%v'", decoded, condition)
+	}
+}
@@ -0,0 +1,60 @@
+package v2
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FromV1 converts v1 conditions to v2 conditions. Zero timestamps become unset,
+// and timestamps are truncated to seconds.
+func FromV1(conditions []conditionsv1.Condition) []Condition {
+	if conditions == nil {
+		return nil
+	}
+	out := make([]Condition, 0, len(conditions))
+	for _, c := range conditions {
+		out = append(out, Condition{
+			Type:               ConditionType(c.Type),
+			Status:             c.Status,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastHeartbeatTime:  fromV1Time(c.LastHeartbeatTime),
+			LastTransitionTime: fromV1Time(c.LastTransitionTime),
+		})
+	}
+	return out
+}
+
+// ToV1 converts v2 conditions to v1 conditions. Unset timestamps become zero.
+func ToV1(conditions []Condition) []conditionsv1.Condition {
+	if conditions == nil {
+		return nil
+	}
+	out := make([]conditionsv1.Condition, 0, len(conditions))
+	for _, c := range conditions {
+		out = append(out, conditionsv1.Condition{
+			Type:               conditionsv1.ConditionType(c.Type),
+			Status:             c.Status,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastHeartbeatTime:  toV1Time(c.LastHeartbeatTime),
+			LastTransitionTime: toV1Time(c.LastTransitionTime),
+		})
+	}
+	return out
+}
+
+func fromV1Time(t metav1.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	truncated := t.Rfc3339Copy()
+	return &truncated
+}
+
+func toV1Time(t *metav1.Time) metav1.Time {
+	if t == nil {
+		return metav1.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,48 @@
+package v2
+
+import (
+	"testing"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConversion(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2021, 1, 1, 12, 0, 0, 500, time.UTC))
+	v1Conditions := []conditionsv1.Condition{
+		{
+			Type:               conditionsv1.ConditionAvailable,
+			Status:             corev1.ConditionTrue,
+			Reason:             "AsExpected",
+			Message:            "All components are available",
+			LastTransitionTime: transition,
+		},
+	}
+
+	v2Conditions := FromV1(v1Conditions)
+	truncated := metav1.NewTime(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC))
+	expected := []Condition{
+		{
+			Type:               ConditionAvailable,
+			Status:             corev1.ConditionTrue,
+			Reason:             "AsExpected",
+			Message:            "All components are available",
+			LastTransitionTime: &truncated,
+		},
+	}
+	if !equality.Semantic.DeepEqual(v2Conditions, expected) {
+		t.Errorf("Unexpected conditions '%v', expected '%v'", v2Conditions, expected)
+	}
+
+	v1Conditions[0].LastTransitionTime = truncated
+	if converted := ToV1(v2Conditions); !equality.Semantic.DeepEqual(converted, v1Conditions) {
+		t.Errorf("Unexpected conditions '%v', expected '%v'", converted, v1Conditions)
+	}
+
+	if FromV1(nil) != nil || ToV1(nil) != nil {
+		t.Error("Expected nil conditions to convert to nil")
+	}
+}
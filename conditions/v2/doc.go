@@ -0,0 +1,8 @@
+// +k8s:deepcopy-gen=package,register
+
+// Package v2 provides version v2 of the types and functions necessary to
+// manage and inspect a slice of conditions. Unlike v1, unset timestamps are
+// omitted from the serialized conditions instead of being serialized as null,
+// and the setters truncate timestamps to the second precision of the
+// serialization, so that conditions are semantically equal after a round trip.
+package v2
@@ -2,8 +2,11 @@ package v1
 
 import (
 	"errors"
+	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var errMinObjectRef = errors.New("object reference must have, at a minimum: apiVersion, kind, and name")
@@ -90,6 +93,27 @@ func ObjectReferenceEqual(gotRef, expectedRef corev1.ObjectReference) bool {
 	return true
 }
 
+// ObjectReferenceFor - returns the reference of object without a scheme.
+// The kind is taken from the TypeMeta of object when set, and from the name of
+// its Go type otherwise, as typed objects usually have an empty TypeMeta.
+// The reference of a nil object is empty.
+func ObjectReferenceFor(object runtime.Object) corev1.ObjectReference {
+	ref := corev1.ObjectReference{}
+	if value := reflect.ValueOf(object); !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return ref
+	}
+	ref.APIVersion, ref.Kind = object.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	if ref.Kind == "" {
+		ref.Kind = reflect.Indirect(reflect.ValueOf(object)).Type().Name()
+	}
+	if accessor, err := meta.Accessor(object); err == nil {
+		ref.Namespace = accessor.GetNamespace()
+		ref.Name = accessor.GetName()
+		ref.UID = accessor.GetUID()
+	}
+	return ref
+}
+
 // in order to have any meaningful semantics on this we need to
 // ensuer that some minimal amount of information is provided in
 // the object reference
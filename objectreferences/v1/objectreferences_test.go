@@ -6,6 +6,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestSetObjectReference(t *testing.T) {
@@ -362,3 +364,52 @@ func TestFindObjectReference(t *testing.T) {
 	}
 	return
 }
+
+func TestObjectReferenceFor(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Namespace: "test-namespace", Name: "foo", UID: "foo-uid"}
+	testCases := []struct {
+		name        string
+		object      runtime.Object
+		expectedRef corev1.ObjectReference
+	}{
+		{
+			name:   "kind from TypeMeta",
+			object: &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "test.example.io/v1", Kind: "ExampleApp"}, ObjectMeta: objectMeta},
+			expectedRef: corev1.ObjectReference{
+				APIVersion: "test.example.io/v1",
+				Kind:       "ExampleApp",
+				Namespace:  "test-namespace",
+				Name:       "foo",
+				UID:        "foo-uid",
+			},
+		},
+		{
+			name:   "kind from Go type",
+			object: &corev1.ConfigMap{ObjectMeta: objectMeta},
+			expectedRef: corev1.ObjectReference{
+				Kind:      "ConfigMap",
+				Namespace: "test-namespace",
+				Name:      "foo",
+				UID:       "foo-uid",
+			},
+		},
+		{
+			name:        "nil object",
+			object:      nil,
+			expectedRef: corev1.ObjectReference{},
+		},
+		{
+			name:        "nil pointer",
+			object:      (*corev1.ConfigMap)(nil),
+			expectedRef: corev1.ObjectReference{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if ref := ObjectReferenceFor(tc.object); ref != tc.expectedRef {
+				t.Errorf("Unexpected object ref '%v', expected '%v'", ref, tc.expectedRef)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package schema provides the apiextensions.k8s.io/v1 structural schemas of
+// object references, to embed or merge into the CustomResourceDefinitions of
+// custom resources with a RelatedObjects field.
+package schema
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ObjectReferences returns the schema of a []corev1.ObjectReference field
+// managed with objectreferences/v1.
+func ObjectReferences() apiextensionsv1.JSONSchemaProps {
+	objectReference := ObjectReference()
+	listType := "atomic"
+	return apiextensionsv1.JSONSchemaProps{
+		Description: "relatedObjects is a list of objects that are \"interesting\" or related to this operator.",
+		Type:        "array",
+		Items:       &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &objectReference},
+		XListType:   &listType,
+	}
+}
+
+// ObjectReference returns the schema of a corev1.ObjectReference. apiVersion,
+// kind and name are required, as objectreferences/v1 requires them.
+func ObjectReference() apiextensionsv1.JSONSchemaProps {
+	mapType := "atomic"
+	return apiextensionsv1.JSONSchemaProps{
+		Description: "ObjectReference contains enough information to let you inspect or modify the referred object.",
+		Type:        "object",
+		Required:    []string{"apiVersion", "kind", "name"},
+		XMapType:    &mapType,
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"apiVersion": {
+				Description: "API version of the referent.",
+				Type:        "string",
+			},
+			"kind": {
+				Description: "Kind of the referent.",
+				Type:        "string",
+			},
+			"name": {
+				Description: "Name of the referent.",
+				Type:        "string",
+			},
+			"namespace": {
+				Description: "Namespace of the referent.",
+				Type:        "string",
+			},
+			"uid": {
+				Description: "UID of the referent.",
+				Type:        "string",
+			},
+			"resourceVersion": {
+				Description: "Specific resourceVersion to which this reference is made, if any.",
+				Type:        "string",
+			},
+			"fieldPath": {
+				Description: "If referring to a piece of an object instead of an entire object, this string should contain a valid JSON/Go field access statement.",
+				Type:        "string",
+			},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestObjectReferences(t *testing.T) {
+	status := apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"relatedObjects": ObjectReferences(),
+		},
+	}
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&status, internal, nil); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	structural, err := structuralschema.NewStructural(internal)
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	if errs := structuralschema.ValidateStructural(field.NewPath("status"), structural); len(errs) > 0 {
+		t.Fatalf("Unexpected non-structural schema: %v", errs.ToAggregate())
+	}
+	validator, _, err := validation.NewSchemaValidator(&apiextensions.CustomResourceValidation{OpenAPIV3Schema: internal})
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		reference     map[string]interface{}
+		expectedValid bool
+	}{
+		{
+			name: "valid",
+			reference: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"namespace":  "test-namespace",
+				"name":       "foo",
+			},
+			expectedValid: true,
+		},
+		{
+			name:          "missing apiVersion",
+			reference:     map[string]interface{}{"kind": "Deployment", "name": "foo"},
+			expectedValid: false,
+		},
+		{
+			name:          "missing kind",
+			reference:     map[string]interface{}{"apiVersion": "apps/v1", "name": "foo"},
+			expectedValid: false,
+		},
+		{
+			name:          "missing name",
+			reference:     map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"},
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			object := map[string]interface{}{"relatedObjects": []interface{}{tc.reference}}
+			if valid := validator.Validate(object).IsValid(); valid != tc.expectedValid {
+				t.Errorf("Unexpected validation result: expected: %t; actual: %t", tc.expectedValid, valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// +k8s:deepcopy-gen=package,register
+
+// Package v1 provides version v1 of the types and functions necessary to
+// report the progress of long-running custom resources, like migrations and
+// backups, and keep their Progressing condition consistent with it.
+package v1
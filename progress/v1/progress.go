@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// now is replaced by tests.
+var now = time.Now
+
+// Start resets progress to process total items from now, starting with step,
+// and sets ConditionProgressing in conditions accordingly.
+// The return value indicates if this resulted in any changes to conditions.
+func Start(progress *Progress, conditions *[]conditionsv1.Condition, total int64, step string) (bool, error) {
+	if total < 0 {
+		return false, fmt.Errorf("invalid progress: total %d must not be negative", total)
+	}
+	startTime := metav1.NewTime(now()).Rfc3339Copy()
+	*progress = Progress{
+		Total:       total,
+		CurrentStep: step,
+		StartTime:   &startTime,
+	}
+	update(progress)
+	return SetProgressingCondition(conditions, *progress), nil
+}
+
+// Update records the items completed and failed so far, and the current
+// step, recomputes the percentage and the estimated completion time of
+// progress, and sets ConditionProgressing in conditions accordingly.
+// The return value indicates if this resulted in any changes to conditions.
+func Update(progress *Progress, conditions *[]conditionsv1.Condition, completed, failed int64, step string) (bool, error) {
+	if completed < 0 || failed < 0 || completed+failed > progress.Total {
+		return false, fmt.Errorf("invalid progress: %d completed and %d failed items out of %d", completed, failed, progress.Total)
+	}
+	progress.Completed = completed
+	progress.Failed = failed
+	progress.CurrentStep = step
+	update(progress)
+	return SetProgressingCondition(conditions, *progress), nil
+}
+
+// update sets the percentage and the estimated completion time of progress.
+func update(progress *Progress) {
+	processed := progress.Completed + progress.Failed
+	if progress.Total == 0 {
+		progress.Percentage = 100
+	} else {
+		progress.Percentage = int32(processed * 100 / progress.Total)
+	}
+
+	progress.EstimatedCompletionTime = nil
+	if progress.StartTime == nil || processed == 0 || IsComplete(progress) {
+		return
+	}
+	current := now()
+	elapsed := current.Sub(progress.StartTime.Time)
+	remaining := time.Duration(float64(elapsed) * float64(progress.Total-processed) / float64(processed))
+	eta := metav1.NewTime(current.Add(remaining)).Rfc3339Copy()
+	progress.EstimatedCompletionTime = &eta
+}
+
+// IsComplete returns true when all the items of progress were processed,
+// successfully or not.
+func IsComplete(progress *Progress) bool {
+	return progress.Completed+progress.Failed >= progress.Total
+}
+
+// SetProgressingCondition sets ConditionProgressing in conditions from
+// progress: True with ReasonInProgress while items remain to process, and
+// False with ReasonCompleted or ReasonCompletedWithFailures once complete.
+// The message describes the current step and the items processed.
+// The return value indicates if this resulted in any changes.
+func SetProgressingCondition(conditions *[]conditionsv1.Condition, progress Progress) bool {
+	condition := conditionsv1.Condition{
+		Type:    conditionsv1.ConditionProgressing,
+		Status:  corev1.ConditionTrue,
+		Reason:  ReasonInProgress,
+		Message: Message(progress),
+	}
+	if IsComplete(&progress) {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = ReasonCompleted
+		if progress.Failed > 0 {
+			condition.Reason = ReasonCompletedWithFailures
+		}
+	}
+	return conditionsv1.SetStatusCondition(conditions, condition)
+}
+
+// Message describes progress, for example "Copying volumes: 3/10 (30%)" or
+// "Copying volumes: 4/10 (50%), 1 failed".
+func Message(progress Progress) string {
+	message := fmt.Sprintf("%d/%d (%d%%)", progress.Completed, progress.Total, progress.Percentage)
+	if progress.CurrentStep != "" {
+		message = progress.CurrentStep + ": " + message
+	}
+	if progress.Failed > 0 {
+		message = fmt.Sprintf("%s, %d failed", message, progress.Failed)
+	}
+	return message
+}
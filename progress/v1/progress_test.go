@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func setNow(current time.Time) {
+	now = func() time.Time { return current }
+}
+
+func TestStart(t *testing.T) {
+	defer func() { now = time.Now }()
+	start := time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC)
+	setNow(start)
+
+	progress := Progress{Total: 3, Completed: 3, Failed: 1}
+	conditions := []conditionsv1.Condition{}
+	changed, err := Start(&progress, &conditions, 10, "Copying volumes")
+	if err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	if !changed {
+		t.Errorf("Unexpected changed 'false', expected 'true'")
+	}
+	if progress.Total != 10 || progress.Completed != 0 || progress.Failed != 0 || progress.Percentage != 0 || progress.CurrentStep != "Copying volumes" {
+		t.Errorf("Unexpected progress '%v', expected it to be reset", progress)
+	}
+	if progress.StartTime == nil || !progress.StartTime.Time.Equal(start) {
+		t.Errorf("Unexpected start time '%v', expected '%v'", progress.StartTime, start)
+	}
+	if progress.EstimatedCompletionTime != nil {
+		t.Errorf("Unexpected estimated completion time '%v', expected '<nil>'", progress.EstimatedCompletionTime)
+	}
+	condition := conditionsv1.FindStatusCondition(conditions, conditionsv1.ConditionProgressing)
+	if condition == nil || condition.Status != corev1.ConditionTrue || condition.Reason != ReasonInProgress || condition.Message != "Copying volumes: 0/10 (0%)" {
+		t.Errorf("Unexpected condition '%v', expected Progressing True", condition)
+	}
+
+	if _, err := Start(&progress, &conditions, -1, ""); err == nil {
+		t.Errorf("Error expected but did not occur")
+	}
+}
+
+func TestStartEmpty(t *testing.T) {
+	progress := Progress{}
+	conditions := []conditionsv1.Condition{}
+	if _, err := Start(&progress, &conditions, 0, ""); err != nil {
+		t.Fatalf("Error occurred unexpectedly: %v", err)
+	}
+	if progress.Percentage != 100 {
+		t.Errorf("Unexpected percentage '%v', expected '100'", progress.Percentage)
+	}
+	if !conditionsv1.IsStatusConditionFalse(conditions, conditionsv1.ConditionProgressing) {
+		t.Errorf("Unexpected conditions '%v', expected Progressing False", conditions)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	defer func() { now = time.Now }()
+	start := time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name              string
+		completed         int64
+		failed            int64
+		step              string
+		shouldError       bool
+		expectedETA       time.Time
+		expectedStatus    corev1.ConditionStatus
+		expectedReason    string
+		expectedMessage   string
+		expectedPercent   int32
+		expectedCompleted int64
+	}{
+		{
+			name:              "in progress",
+			completed:         2,
+			step:              "Copying volumes",
+			expectedETA:       start.Add(40 * time.Minute),
+			expectedStatus:    corev1.ConditionTrue,
+			expectedReason:    ReasonInProgress,
+			expectedMessage:   "Copying volumes: 2/10 (20%)",
+			expectedPercent:   20,
+			expectedCompleted: 2,
+		},
+		{
+			name:              "in progress with failures",
+			completed:         4,
+			failed:            1,
+			step:              "Copying volumes",
+			expectedETA:       start.Add(10 * time.Minute),
+			expectedStatus:    corev1.ConditionTrue,
+			expectedReason:    ReasonInProgress,
+			expectedMessage:   "Copying volumes: 4/10 (50%), 1 failed",
+			expectedPercent:   50,
+			expectedCompleted: 4,
+		},
+		{
+			name:              "completed",
+			completed:         10,
+			expectedStatus:    corev1.ConditionFalse,
+			expectedReason:    ReasonCompleted,
+			expectedMessage:   "10/10 (100%)",
+			expectedPercent:   100,
+			expectedCompleted: 10,
+		},
+		{
+			name:              "completed with failures",
+			completed:         8,
+			failed:            2,
+			expectedStatus:    corev1.ConditionFalse,
+			expectedReason:    ReasonCompletedWithFailures,
+			expectedMessage:   "8/10 (100%), 2 failed",
+			expectedPercent:   100,
+			expectedCompleted: 8,
+		},
+		{
+			name:        "too many items",
+			completed:   10,
+			failed:      1,
+			shouldError: true,
+		},
+		{
+			name:        "negative items",
+			completed:   -1,
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setNow(start.Add(-10*time.Minute))
+			progress := Progress{}
+			conditions := []conditionsv1.Condition{}
+			if _, err := Start(&progress, &conditions, 10, "Preparing"); err != nil {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+
+			setNow(start)
+			_, err := Update(&progress, &conditions, tc.completed, tc.failed, tc.step)
+			if err != nil && !tc.shouldError {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+			if err == nil && tc.shouldError {
+				t.Fatalf("Error expected but did not occur")
+			}
+			if tc.shouldError {
+				return
+			}
+
+			if progress.Completed != tc.expectedCompleted || progress.Failed != tc.failed || progress.CurrentStep != tc.step {
+				t.Errorf("Unexpected progress '%v'", progress)
+			}
+			if progress.Percentage != tc.expectedPercent {
+				t.Errorf("Unexpected percentage '%v', expected '%v'", progress.Percentage, tc.expectedPercent)
+			}
+			if tc.expectedETA.IsZero() {
+				if progress.EstimatedCompletionTime != nil {
+					t.Errorf("Unexpected estimated completion time '%v', expected '<nil>'", progress.EstimatedCompletionTime)
+				}
+			} else if progress.EstimatedCompletionTime == nil || !progress.EstimatedCompletionTime.Time.Equal(tc.expectedETA) {
+				t.Errorf("Unexpected estimated completion time '%v', expected '%v'", progress.EstimatedCompletionTime, tc.expectedETA)
+			}
+
+			condition := conditionsv1.FindStatusCondition(conditions, conditionsv1.ConditionProgressing)
+			if condition == nil {
+				t.Fatalf("Unexpected condition '<nil>', expected Progressing")
+			}
+			if condition.Status != tc.expectedStatus {
+				t.Errorf("Unexpected status '%v', expected '%v'", condition.Status, tc.expectedStatus)
+			}
+			if condition.Reason != tc.expectedReason {
+				t.Errorf("Unexpected reason '%v', expected '%v'", condition.Reason, tc.expectedReason)
+			}
+			if condition.Message != tc.expectedMessage {
+				t.Errorf("Unexpected message '%v', expected '%v'", condition.Message, tc.expectedMessage)
+			}
+		})
+	}
+}
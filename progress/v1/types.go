@@ -0,0 +1,50 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Progress represents the progress of a long-running operation over a number
+// of items, like the objects of a migration or the volumes of a backup.
+// +k8s:deepcopy-gen=true
+type Progress struct {
+	// total number of items to process.
+	Total int64 `json:"total" description:"total number of items to process"`
+
+	// number of items processed successfully.
+	Completed int64 `json:"completed" description:"number of items processed successfully"`
+
+	// number of items that failed to process.
+	// +optional
+	Failed int64 `json:"failed,omitempty" description:"number of items that failed to process"`
+
+	// human-readable description of the current step.
+	// +optional
+	CurrentStep string `json:"currentStep,omitempty" description:"human-readable description of the current step"`
+
+	// percentage of the items processed, successfully or not, from 0 to 100.
+	Percentage int32 `json:"percentage" description:"percentage of the items processed, successfully or not, from 0 to 100"`
+
+	// time the operation started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty" description:"time the operation started"`
+
+	// estimated time the operation completes, extrapolated from the items
+	// processed since the start time.
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty" description:"estimated time the operation completes"`
+}
+
+const (
+	// ReasonInProgress is the reason of ConditionProgressing while items
+	// remain to process.
+	ReasonInProgress = "InProgress"
+
+	// ReasonCompleted is the reason of ConditionProgressing once all the items
+	// were processed successfully.
+	ReasonCompleted = "Completed"
+
+	// ReasonCompletedWithFailures is the reason of ConditionProgressing once
+	// all the items were processed and some failed.
+	ReasonCompletedWithFailures = "CompletedWithFailures"
+)
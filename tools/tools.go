@@ -1,4 +1,7 @@
 // Package tools imports things required by build scripts
 package tools
 
-import _ "k8s.io/code-generator" // simply to force `go mod` to see them as dependencies
+import (
+	_ "k8s.io/code-generator"              // simply to force `go mod` to see them as dependencies
+	_ "k8s.io/kube-openapi/pkg/generators" // simply to force `go mod` to see them as dependencies
+)
@@ -8,7 +8,6 @@ verify="${VERIFY:-}"
 bash ${CODEGEN_PKG}/generate-groups.sh "deepcopy" \
 	github.com/openshift/custom-resource-status/generated \
 	github.com/openshift/custom-resource-status \
-	"conditions:v1" \
-	"objectreferences:v1" \
+	"conditions:v1,v2 objectreferences:v1 progress:v1 versions:v1" \
 	--go-header-file ${SCRIPT_ROOT}/tools/empty.txt \
 	${verify}
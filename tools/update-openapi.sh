@@ -0,0 +1,31 @@
+#!/bin/bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT=$(dirname ${BASH_SOURCE})/..
+PKG=github.com/openshift/custom-resource-status
+OUTPUT_BASE=$(mktemp -d)
+trap "rm -rf ${OUTPUT_BASE}" EXIT
+
+verify="${VERIFY:-}"
+
+for api in "conditions/v1"; do
+	go run k8s.io/kube-openapi/cmd/openapi-gen \
+		--input-dirs ${PKG}/${api} \
+		--output-package ${PKG}/${api} \
+		--output-file-base zz_generated.openapi \
+		--go-header-file ${SCRIPT_ROOT}/tools/empty.txt \
+		--output-base ${OUTPUT_BASE}
+
+	generated=${OUTPUT_BASE}/${PKG}/${api}/zz_generated.openapi.go
+	if [ -n "${verify}" ]; then
+		if ! diff -u ${SCRIPT_ROOT}/${api}/zz_generated.openapi.go ${generated}; then
+			echo "${api}/zz_generated.openapi.go is out of date, run 'make update-openapi'" >&2
+			exit 1
+		fi
+	else
+		cp ${generated} ${SCRIPT_ROOT}/${api}/zz_generated.openapi.go
+	fi
+done
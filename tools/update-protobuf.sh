@@ -0,0 +1,51 @@
+#!/bin/bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# go-to-protobuf requires protoc 3.x and goimports in the PATH. It reads and
+# writes packages in a GOPATH layout, which is set up in OUTPUT_BASE.
+
+SCRIPT_ROOT=$(cd $(dirname ${BASH_SOURCE})/.. && pwd)
+PKG=github.com/openshift/custom-resource-status
+OUTPUT_BASE=$(mktemp -d)
+trap "rm -rf ${OUTPUT_BASE}" EXIT
+
+verify="${VERIFY:-}"
+
+# go-to-protobuf rewrites the sources in place, verify a copy of them.
+target=${SCRIPT_ROOT}
+if [ -n "${verify}" ]; then
+	target=${OUTPUT_BASE}/custom-resource-status
+	cp -R ${SCRIPT_ROOT} ${target}
+fi
+
+for module in k8s.io/api k8s.io/apimachinery github.com/gogo/protobuf; do
+	mkdir -p ${OUTPUT_BASE}/src/$(dirname ${module})
+	ln -s $(cd ${SCRIPT_ROOT} && go list -m -f '{{.Dir}}' ${module}) ${OUTPUT_BASE}/src/${module}
+done
+mkdir -p ${OUTPUT_BASE}/src/$(dirname ${PKG})
+ln -s ${target} ${OUTPUT_BASE}/src/${PKG}
+
+mkdir -p ${OUTPUT_BASE}/bin
+(cd ${SCRIPT_ROOT} && go build -o ${OUTPUT_BASE}/bin/ k8s.io/code-generator/cmd/go-to-protobuf k8s.io/code-generator/cmd/go-to-protobuf/protoc-gen-gogo)
+
+for api in "conditions/v1"; do
+	(cd ${target} && PATH=${OUTPUT_BASE}/bin:${PATH} go-to-protobuf \
+		--output-base ${OUTPUT_BASE}/src \
+		--vendor-output-base ${OUTPUT_BASE}/vendor \
+		--apimachinery-packages -k8s.io/apimachinery/pkg/runtime/schema,-k8s.io/apimachinery/pkg/runtime,-k8s.io/apimachinery/pkg/apis/meta/v1,-k8s.io/api/core/v1 \
+		--packages ${PKG}/${api} \
+		--proto-import ${OUTPUT_BASE}/src/github.com/gogo/protobuf/protobuf \
+		--go-header-file ${SCRIPT_ROOT}/tools/empty.txt)
+
+	if [ -n "${verify}" ]; then
+		for file in generated.proto generated.pb.go types.go; do
+			if ! diff -u ${SCRIPT_ROOT}/${api}/${file} ${target}/${api}/${file}; then
+				echo "${api}/${file} is out of date, run 'make update-protobuf'" >&2
+				exit 1
+			fi
+		done
+	fi
+done
@@ -0,0 +1,7 @@
+// +k8s:deepcopy-gen=package,register
+
+// Package v1 provides version v1 of the types and functions necessary to
+// manage and inspect a slice of operand versions. This can be used to add a
+// Versions field on the status of your custom resource, reporting the
+// versions of the operands your operator manages like ClusterOperator does.
+package v1
@@ -0,0 +1,11 @@
+package v1
+
+// OperandVersion is the version of an operand managed by the operator.
+// +k8s:deepcopy-gen=true
+type OperandVersion struct {
+	// name of the operand, like operator or the name of a managed component.
+	Name string `json:"name" description:"name of the operand"`
+
+	// version of the operand, usually a semantic version.
+	Version string `json:"version" description:"version of the operand"`
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"errors"
+)
+
+var errMinOperandVersion = errors.New("operand version must have, at a minimum: name")
+
+// SetOperandVersion - updates list of operand versions based on newVersion
+func SetOperandVersion(versions *[]OperandVersion, newVersion OperandVersion) error {
+	if newVersion.Name == "" {
+		return errMinOperandVersion
+	}
+
+	if versions == nil {
+		versions = &[]OperandVersion{}
+	}
+	existingVersion := FindOperandVersion(*versions, newVersion.Name)
+	if existingVersion == nil { // add it to the slice
+		*versions = append(*versions, newVersion)
+	} else { // update found version
+		*existingVersion = newVersion
+	}
+	return nil
+}
+
+// RemoveOperandVersion - updates list of operand versions to remove the
+// operand named name
+func RemoveOperandVersion(versions *[]OperandVersion, name string) {
+	if versions == nil {
+		return
+	}
+	newVersions := []OperandVersion{}
+	for _, version := range *versions {
+		if version.Name != name {
+			newVersions = append(newVersions, version)
+		}
+	}
+
+	*versions = newVersions
+}
+
+// FindOperandVersion - finds the OperandVersion of the operand named name in
+// a slice of versions.
+func FindOperandVersion(versions []OperandVersion, name string) *OperandVersion {
+	for i := range versions {
+		if versions[i].Name == name {
+			return &versions[i]
+		}
+	}
+
+	return nil
+}
+
+// PendingOperandVersions returns the targets whose operand is missing from
+// versions or reports another version, in the order of targets.
+func PendingOperandVersions(versions []OperandVersion, targets []OperandVersion) []OperandVersion {
+	pending := []OperandVersion{}
+	for _, target := range targets {
+		version := FindOperandVersion(versions, target.Name)
+		if version == nil || version.Version != target.Version {
+			pending = append(pending, target)
+		}
+	}
+	return pending
+}
+
+// IsAtOperandVersions returns true when every operand of targets reports its
+// target version in versions.
+func IsAtOperandVersions(versions []OperandVersion, targets []OperandVersion) bool {
+	return len(PendingOperandVersions(versions, targets)) == 0
+}
+
+// IsAtVersion returns true when all the operands in versions report version,
+// or only the operands named in names when provided. A named operand missing
+// from versions has not reached version.
+func IsAtVersion(versions []OperandVersion, version string, names ...string) bool {
+	if len(names) == 0 {
+		for _, v := range versions {
+			if v.Version != version {
+				return false
+			}
+		}
+		return true
+	}
+
+	targets := make([]OperandVersion, len(names))
+	for i, name := range names {
+		targets[i] = OperandVersion{Name: name, Version: version}
+	}
+	return IsAtOperandVersions(versions, targets)
+}
@@ -0,0 +1,203 @@
+package v1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestSetOperandVersion(t *testing.T) {
+	testCases := []struct {
+		name             string
+		testVersion      OperandVersion
+		startVersions    []OperandVersion
+		expectedVersions []OperandVersion
+		shouldError      bool
+	}{
+		{
+			name:             "add when empty",
+			testVersion:      OperandVersion{Name: "operator", Version: "1.0.0"},
+			startVersions:    []OperandVersion{},
+			expectedVersions: []OperandVersion{{Name: "operator", Version: "1.0.0"}},
+		},
+		{
+			name:             "simple add",
+			testVersion:      OperandVersion{Name: "operand", Version: "2.0.0"},
+			startVersions:    []OperandVersion{{Name: "operator", Version: "1.0.0"}},
+			expectedVersions: []OperandVersion{{Name: "operator", Version: "1.0.0"}, {Name: "operand", Version: "2.0.0"}},
+		},
+		{
+			name:             "update",
+			testVersion:      OperandVersion{Name: "operator", Version: "1.1.0"},
+			startVersions:    []OperandVersion{{Name: "operator", Version: "1.0.0"}, {Name: "operand", Version: "2.0.0"}},
+			expectedVersions: []OperandVersion{{Name: "operator", Version: "1.1.0"}, {Name: "operand", Version: "2.0.0"}},
+		},
+		{
+			name:             "missing name",
+			testVersion:      OperandVersion{Version: "1.0.0"},
+			startVersions:    []OperandVersion{{Name: "operator", Version: "1.0.0"}},
+			expectedVersions: []OperandVersion{{Name: "operator", Version: "1.0.0"}},
+			shouldError:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			versions := tc.startVersions
+			err := SetOperandVersion(&versions, tc.testVersion)
+			if err != nil && !tc.shouldError {
+				t.Fatalf("Error occurred unexpectedly: %v", err)
+			}
+			if err == nil && tc.shouldError {
+				t.Fatalf("Error expected but did not occur")
+			}
+			if !equality.Semantic.DeepEqual(versions, tc.expectedVersions) {
+				t.Errorf("Unexpected versions '%v', expected '%v'", versions, tc.expectedVersions)
+			}
+		})
+	}
+}
+
+func TestRemoveOperandVersion(t *testing.T) {
+	testCases := []struct {
+		name             string
+		testName         string
+		startVersions    []OperandVersion
+		expectedVersions []OperandVersion
+	}{
+		{
+			name:             "remove when empty",
+			testName:         "operator",
+			startVersions:    []OperandVersion{},
+			expectedVersions: []OperandVersion{},
+		},
+		{
+			name:             "simple remove",
+			testName:         "operator",
+			startVersions:    []OperandVersion{{Name: "operator", Version: "1.0.0"}, {Name: "operand", Version: "2.0.0"}},
+			expectedVersions: []OperandVersion{{Name: "operand", Version: "2.0.0"}},
+		},
+		{
+			name:             "remove missing",
+			testName:         "missing",
+			startVersions:    []OperandVersion{{Name: "operator", Version: "1.0.0"}},
+			expectedVersions: []OperandVersion{{Name: "operator", Version: "1.0.0"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			versions := tc.startVersions
+			RemoveOperandVersion(&versions, tc.testName)
+			if !equality.Semantic.DeepEqual(versions, tc.expectedVersions) {
+				t.Errorf("Unexpected versions '%v', expected '%v'", versions, tc.expectedVersions)
+			}
+		})
+	}
+
+	RemoveOperandVersion(nil, "operator")
+}
+
+func TestFindOperandVersion(t *testing.T) {
+	versions := []OperandVersion{{Name: "operator", Version: "1.0.0"}, {Name: "operand", Version: "2.0.0"}}
+
+	found := FindOperandVersion(versions, "operand")
+	if found == nil || *found != versions[1] {
+		t.Errorf("Unexpected version '%v', expected '%v'", found, versions[1])
+	}
+	found.Version = "2.1.0"
+	if versions[1].Version != "2.1.0" {
+		t.Errorf("Unexpected version '%v', expected the found version to be updated in place", versions[1].Version)
+	}
+	if found := FindOperandVersion(versions, "missing"); found != nil {
+		t.Errorf("Unexpected version '%v', expected '<nil>'", found)
+	}
+}
+
+func TestPendingOperandVersions(t *testing.T) {
+	versions := []OperandVersion{{Name: "operator", Version: "1.1.0"}, {Name: "operand", Version: "2.0.0"}}
+
+	testCases := []struct {
+		name            string
+		targets         []OperandVersion
+		expectedPending []OperandVersion
+	}{
+		{
+			name:            "no targets",
+			targets:         nil,
+			expectedPending: []OperandVersion{},
+		},
+		{
+			name:            "all reached",
+			targets:         []OperandVersion{{Name: "operator", Version: "1.1.0"}, {Name: "operand", Version: "2.0.0"}},
+			expectedPending: []OperandVersion{},
+		},
+		{
+			name:            "other version",
+			targets:         []OperandVersion{{Name: "operator", Version: "1.1.0"}, {Name: "operand", Version: "2.1.0"}},
+			expectedPending: []OperandVersion{{Name: "operand", Version: "2.1.0"}},
+		},
+		{
+			name:            "missing operand",
+			targets:         []OperandVersion{{Name: "new-operand", Version: "1.0.0"}, {Name: "operator", Version: "1.1.0"}},
+			expectedPending: []OperandVersion{{Name: "new-operand", Version: "1.0.0"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pending := PendingOperandVersions(versions, tc.targets)
+			if !equality.Semantic.DeepEqual(pending, tc.expectedPending) {
+				t.Errorf("Unexpected pending versions '%v', expected '%v'", pending, tc.expectedPending)
+			}
+			if reached := IsAtOperandVersions(versions, tc.targets); reached != (len(tc.expectedPending) == 0) {
+				t.Errorf("Unexpected reached '%v', expected '%v'", reached, len(tc.expectedPending) == 0)
+			}
+		})
+	}
+}
+
+func TestIsAtVersion(t *testing.T) {
+	testCases := []struct {
+		name          string
+		versions      []OperandVersion
+		names         []string
+		expectedAtVer bool
+	}{
+		{
+			name:          "no versions",
+			versions:      nil,
+			expectedAtVer: true,
+		},
+		{
+			name:          "all at version",
+			versions:      []OperandVersion{{Name: "operator", Version: "4.10.0"}, {Name: "operand", Version: "4.10.0"}},
+			expectedAtVer: true,
+		},
+		{
+			name:          "one behind",
+			versions:      []OperandVersion{{Name: "operator", Version: "4.10.0"}, {Name: "operand", Version: "4.9.0"}},
+			expectedAtVer: false,
+		},
+		{
+			name:          "named at version",
+			versions:      []OperandVersion{{Name: "operator", Version: "4.10.0"}, {Name: "operand", Version: "4.9.0"}},
+			names:         []string{"operator"},
+			expectedAtVer: true,
+		},
+		{
+			name:          "named missing",
+			versions:      []OperandVersion{{Name: "operator", Version: "4.10.0"}},
+			names:         []string{"operator", "operand"},
+			expectedAtVer: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if atVersion := IsAtVersion(tc.versions, "4.10.0", tc.names...); atVersion != tc.expectedAtVer {
+				t.Errorf("Unexpected at version '%v', expected '%v'", atVersion, tc.expectedAtVer)
+			}
+		})
+	}
+}